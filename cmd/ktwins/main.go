@@ -1,38 +1,88 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"ktwins/internal/config"
+	"ktwins/internal/data"
+	"ktwins/internal/kube"
 	"ktwins/internal/ui"
 )
 
 func main() {
 	rest.SetDefaultWarningHandler(rest.NoWarnings{})
+	// erros de fundo do client-go (port-forward, watch) iriam para o stderr
+	// por cima da TUI
+	klog.LogToStderr(false)
+	klog.SetOutput(io.Discard)
 
-	ns := ""
-	if len(os.Args) > 1 {
-		ns = os.Args[1]
+	var (
+		scope       data.Scope
+		contextName string
+		twinNS      string
+		twinContext string
+		saveDir     string
+	)
+	flag.StringVar(&contextName, "context", "", "contexto do kubeconfig (padrão: current-context)")
+	flag.StringVar(&scope.Labels, "l", "", "seletor de labels, como no kubectl (ex.: app=checkout)")
+	flag.StringVar(&scope.Labels, "selector", "", "o mesmo que -l")
+	flag.StringVar(&scope.Fields, "field-selector", "", "seletor de campos, como no kubectl (ex.: status.phase=Running)")
+	flag.StringVar(&twinNS, "twin", "", "abre o modo twins comparando com este namespace")
+	flag.StringVar(&twinContext, "twin-context", "", "contexto do lado direito do modo twins (padrão: o mesmo)")
+	flag.StringVar(&saveDir, "save-dir", "", "diretório onde [w]/[W] gravam logs e describes (padrão: saveDir do config.json ou o diretório atual)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "uso: %s [flags] [namespace]\n", os.Args[0])
+		flag.PrintDefaults()
 	}
-
-	kubeconfig := os.Getenv("KUBECONFIG")
-	if kubeconfig == "" {
-		kubeconfig = os.ExpandEnv("$HOME/.kube/config")
+	flag.Parse()
+	scope.Namespace = flag.Arg(0)
+	if err := scope.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "seletor inválido:", err)
+		os.Exit(2)
 	}
 
-	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	kubeconfig, err := kube.Load()
 	if err != nil {
 		panic(err)
 	}
-
-	clientset, err := kubernetes.NewForConfig(cfg)
+	session, err := kubeconfig.Connect(contextName)
 	if err != nil {
 		panic(err)
 	}
 
-	dash := ui.NewDashboard(ns, clientset)
+	// Por padrão os dados vêm de informers; KTWINS_BACKEND=kubectl mantém o caminho antigo como fallback.
+	newBackend := func(s *kube.Session) data.Backend {
+		return data.NewCache(s.Clientset)
+	}
+	if strings.EqualFold(os.Getenv("KTWINS_BACKEND"), "kubectl") {
+		newBackend = func(s *kube.Session) data.Backend {
+			return data.NewKubectlBackend(s.Name)
+		}
+	}
+
+	// preferências ilegíveis não impedem o uso; ficam os padrões
+	prefs, _ := config.Load()
+	if flag.NArg() == 0 {
+		scope.Namespaces = prefs.Namespaces[session.Server]
+	}
+
+	dash := ui.NewDashboard(scope, session, kubeconfig, newBackend, prefs)
+	if saveDir == "" {
+		saveDir = prefs.SaveDir
+	}
+	dash.SetSaveDir(saveDir)
+	if twinNS != "" || twinContext != "" {
+		if err := dash.Twin(twinNS, twinContext); err != nil {
+			fmt.Fprintln(os.Stderr, "twins:", err)
+			os.Exit(2)
+		}
+	}
 	if err := dash.Run(); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config são as preferências que sobrevivem entre sessões, gravadas em
+// <os.UserConfigDir>/ktwins/config.json.
+type Config struct {
+	// Namespaces marcados no box NAMESPACES, por cluster (URL do apiserver).
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+	// Últimos namespaces usados, por cluster, do mais recente ao mais antigo.
+	RecentNamespaces map[string][]string `json:"recentNamespaces,omitempty"`
+	// Diretório onde o modal grava logs e describes; vazio = diretório atual.
+	// A flag -save-dir tem precedência.
+	SaveDir string `json:"saveDir,omitempty"`
+
+	path string
+}
+
+// Load lê o arquivo de preferências; se ele ainda não existe, devolve um Config vazio.
+func Load() (*Config, error) {
+	cfg := &Config{Namespaces: map[string][]string{}, RecentNamespaces: map[string][]string{}}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.path = filepath.Join(dir, "ktwins", "config.json")
+	raw, err := os.ReadFile(cfg.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.Namespaces == nil {
+		cfg.Namespaces = map[string][]string{}
+	}
+	if cfg.RecentNamespaces == nil {
+		cfg.RecentNamespaces = map[string][]string{}
+	}
+	return cfg, nil
+}
+
+// Save grava via arquivo temporário + rename, para não deixar JSON pela metade.
+func (c *Config) Save() error {
+	if c.path == "" {
+		return errors.New("diretório de configuração indisponível")
+	}
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
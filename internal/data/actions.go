@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kinds aceitos por cada ação sobre workloads.
+var (
+	ScaleKinds   = map[string]bool{"deploy": true, "sts": true}
+	RestartKinds = map[string]bool{"deploy": true, "sts": true, "ds": true}
+	PauseKinds   = map[string]bool{"deploy": true}
+)
+
+// Replicas devolve o número de réplicas desejado (subrecurso scale).
+func Replicas(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) (int32, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	switch kind {
+	case "deploy":
+		s, err := c.AppsV1().Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return s.Spec.Replicas, nil
+	case "sts":
+		s, err := c.AppsV1().StatefulSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return s.Spec.Replicas, nil
+	}
+	return 0, fmt.Errorf("%s não escala", kind)
+}
+
+// Scale ajusta spec.replicas, como "kubectl scale".
+func Scale(ctx context.Context, c kubernetes.Interface, kind, namespace, name string, replicas int32) error {
+	if !ScaleKinds[kind] {
+		return fmt.Errorf("%s não escala", kind)
+	}
+	return patchWorkload(ctx, c, kind, namespace, name, fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+}
+
+// RolloutRestart recria os pods como "kubectl rollout restart": muda a
+// anotação restartedAt do template.
+func RolloutRestart(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) error {
+	if !RestartKinds[kind] {
+		return fmt.Errorf("%s não tem rollout", kind)
+	}
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339))
+	return patchWorkload(ctx, c, kind, namespace, name, patch)
+}
+
+// SetRolloutPaused pausa ou retoma o rollout de um deployment
+// ("kubectl rollout pause/resume").
+func SetRolloutPaused(ctx context.Context, c kubernetes.Interface, kind, namespace, name string, paused bool) error {
+	if !PauseKinds[kind] {
+		return fmt.Errorf("%s não pausa rollout", kind)
+	}
+	return patchWorkload(ctx, c, kind, namespace, name, fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+}
+
+func patchWorkload(ctx context.Context, c kubernetes.Interface, kind, namespace, name, patch string) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	opts := metav1.PatchOptions{}
+	var err error
+	switch kind {
+	case "deploy":
+		_, err = c.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), opts)
+	case "sts":
+		_, err = c.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), opts)
+	case "ds":
+		_, err = c.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), opts)
+	default:
+		err = fmt.Errorf("%s não suportado", kind)
+	}
+	return err
+}
@@ -0,0 +1,296 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/pager"
+)
+
+const (
+	apiTimeout   = 5 * time.Second // por página
+	listPageSize = 500             // mesmo chunk-size padrão do kubectl
+)
+
+// Backend abstrai a origem dos objetos exibidos pelo dashboard.
+// Os kinds seguem os nomes curtos do kubectl ("pods", "deploy", "svc", ...).
+type Backend interface {
+	List(kind string, s Scope) ([]runtime.Object, error)
+	PodMetrics(ns string) ([]PodMetrics, error)
+}
+
+// PodMetrics é o subconjunto de metrics.k8s.io/v1beta1 usado em "top pods".
+type PodMetrics struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Containers        []ContainerMetrics `json:"containers"`
+}
+
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+var clusterScoped = map[string]bool{
+	"nodes": true,
+	"pv":    true,
+	"crd":   true,
+	"ns":    true,
+}
+
+// ClusterScoped diz se o kind não vive em namespace (nodes, pv, crd, ns).
+func ClusterScoped(kind string) bool {
+	return clusterScoped[kind]
+}
+
+const (
+	crdPath     = "/apis/apiextensions.k8s.io/v1/customresourcedefinitions"
+	metricsPath = "/apis/metrics.k8s.io/v1beta1"
+)
+
+func metricsPodsPath(ns string) string {
+	if target := namespaceTarget(ns); target != metav1.NamespaceAll {
+		return fmt.Sprintf("%s/namespaces/%s/pods", metricsPath, target)
+	}
+	return metricsPath + "/pods"
+}
+
+func namespaceTarget(ns string) string {
+	trimmed := strings.TrimSpace(ns)
+	if trimmed == "" || strings.EqualFold(trimmed, "all") {
+		return metav1.NamespaceAll
+	}
+	return trimmed
+}
+
+func allNamespaces(ns string) bool {
+	return namespaceTarget(ns) == metav1.NamespaceAll
+}
+
+// clientBackend lista tudo via client-go, sem depender do binário kubectl.
+type clientBackend struct {
+	c *kubernetes.Clientset
+}
+
+func NewClientBackend(c *kubernetes.Clientset) Backend {
+	return &clientBackend{c: c}
+}
+
+// List pagina com Limit/Continue (via pager, que refaz a listagem inteira se
+// o token de continuação expirar), então não há teto de objetos por kind.
+// Os seletores do Scope vão para o apiserver.
+func (b *clientBackend) List(kind string, s Scope) ([]runtime.Object, error) {
+	target := namespaceTarget(s.Namespace)
+	p := pager.New(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		defer cancel()
+		return b.listPage(ctx, kind, target, opts)
+	})
+	p.PageSize = listPageSize
+
+	var objs []runtime.Object
+	err := p.EachListItem(context.Background(), s.listOptions(), func(obj runtime.Object) error {
+		objs = append(objs, obj)
+		return nil
+	})
+	if unsupportedField(s, err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func (b *clientBackend) listPage(ctx context.Context, kind, target string, opts metav1.ListOptions) (runtime.Object, error) {
+	var (
+		list runtime.Object
+		err  error
+	)
+	switch kind {
+	case "deploy":
+		list, err = b.c.AppsV1().Deployments(target).List(ctx, opts)
+	case "rs":
+		list, err = b.c.AppsV1().ReplicaSets(target).List(ctx, opts)
+	case "sts":
+		list, err = b.c.AppsV1().StatefulSets(target).List(ctx, opts)
+	case "ds":
+		list, err = b.c.AppsV1().DaemonSets(target).List(ctx, opts)
+	case "jobs":
+		list, err = b.c.BatchV1().Jobs(target).List(ctx, opts)
+	case "cronjobs":
+		list, err = b.c.BatchV1().CronJobs(target).List(ctx, opts)
+	case "pods":
+		list, err = b.c.CoreV1().Pods(target).List(ctx, opts)
+	case "svc":
+		list, err = b.c.CoreV1().Services(target).List(ctx, opts)
+	case "ingress":
+		list, err = b.c.NetworkingV1().Ingresses(target).List(ctx, opts)
+	case "endpoints":
+		list, err = b.c.CoreV1().Endpoints(target).List(ctx, opts)
+	case "pvc":
+		list, err = b.c.CoreV1().PersistentVolumeClaims(target).List(ctx, opts)
+	case "secrets":
+		list, err = b.c.CoreV1().Secrets(target).List(ctx, opts)
+	case "configmaps":
+		list, err = b.c.CoreV1().ConfigMaps(target).List(ctx, opts)
+	case "serviceaccounts":
+		list, err = b.c.CoreV1().ServiceAccounts(target).List(ctx, opts)
+	case "events":
+		list, err = b.c.CoreV1().Events(target).List(ctx, opts)
+	case "nodes":
+		list, err = b.c.CoreV1().Nodes().List(ctx, opts)
+	case "pv":
+		list, err = b.c.CoreV1().PersistentVolumes().List(ctx, opts)
+	case "ns":
+		list, err = b.c.CoreV1().Namespaces().List(ctx, opts)
+	case "crd":
+		// CRDs ficam fora do clientset tipado; lemos só os metadados.
+		var raw []byte
+		req := b.c.Discovery().RESTClient().Get().AbsPath(crdPath)
+		if opts.Limit > 0 {
+			req = req.Param("limit", strconv.FormatInt(opts.Limit, 10))
+		}
+		if opts.Continue != "" {
+			req = req.Param("continue", opts.Continue)
+		}
+		if opts.LabelSelector != "" {
+			req = req.Param("labelSelector", opts.LabelSelector)
+		}
+		if opts.FieldSelector != "" {
+			req = req.Param("fieldSelector", opts.FieldSelector)
+		}
+		raw, err = req.Do(ctx).Raw()
+		if err == nil {
+			list, err = decodeMetadataList(raw)
+		}
+	default:
+		return nil, fmt.Errorf("kind não suportado: %s", kind)
+	}
+	return list, err
+}
+
+func (b *clientBackend) PodMetrics(ns string) ([]PodMetrics, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	raw, err := b.c.Discovery().RESTClient().Get().AbsPath(metricsPodsPath(ns)).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	return decodePodMetrics(raw)
+}
+
+// kubectlBackend é o fallback: mesmo contrato, mas via "kubectl get -o json".
+// context fixa o contexto do kubeconfig; vazio segue SetKubectlContext.
+type kubectlBackend struct {
+	context string
+}
+
+func NewKubectlBackend(context string) Backend {
+	return kubectlBackend{context: context}
+}
+
+func (b kubectlBackend) List(kind string, s Scope) ([]runtime.Object, error) {
+	args := []string{"get", kind, "-o", "json"}
+	if !clusterScoped[kind] {
+		args = append(args, NSSelector(s.Namespace, true)...)
+	}
+	opts := s.listOptions()
+	if opts.LabelSelector != "" {
+		args = append(args, "-l", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		args = append(args, "--field-selector", opts.FieldSelector)
+	}
+	raw, err := runKubectlJSON(b.context, args...)
+	if opts.FieldSelector != "" && err != nil && strings.Contains(err.Error(), "field label not supported") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kind == "crd" {
+		list, err := decodeMetadataList(raw)
+		if err != nil {
+			return nil, err
+		}
+		return meta.ExtractList(list)
+	}
+
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	decoder := scheme.Codecs.UniversalDeserializer()
+	objs := make([]runtime.Object, 0, len(list.Items))
+	for _, item := range list.Items {
+		obj, _, err := decoder.Decode(item, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (b kubectlBackend) PodMetrics(ns string) ([]PodMetrics, error) {
+	raw, err := runKubectlJSON(b.context, "get", "--raw", metricsPodsPath(ns))
+	if err != nil {
+		return nil, err
+	}
+	return decodePodMetrics(raw)
+}
+
+// Diferente de runKubectl: sem limite de saída (JSON truncado não decodifica).
+func runKubectlJSON(kubeContext string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+
+	full := append(kubectlFlags(apiTimeout, kubeContext), args...)
+	c := exec.CommandContext(ctx, "kubectl", full...)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, errors.New("timeout")
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeMetadataList(raw []byte) (*metav1.PartialObjectMetadataList, error) {
+	var list metav1.PartialObjectMetadataList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func decodePodMetrics(raw []byte) ([]PodMetrics, error) {
+	var list struct {
+		Items []PodMetrics `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,390 @@
+package data
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Watcher é implementado por backends que avisam mudanças em vez de exigir polling.
+type Watcher interface {
+	Start(onChange func(kind string))
+	Stop()
+	SyncState(kind string) SyncState
+	// Watch liga o watch de kinds sob demanda (lazyKinds) quando o box
+	// deles aparece na tela.
+	Watch(kinds ...string)
+}
+
+// SyncState descreve o último evento recebido de um kind observado.
+type SyncState struct {
+	Watched bool
+	Synced  bool
+	At      time.Time
+	Err     error
+}
+
+var watchedKinds = []string{
+	"deploy", "rs", "sts", "ds", "jobs", "cronjobs",
+	"pods", "svc", "ingress", "endpoints",
+	"pvc", "pv", "secrets", "configmaps", "serviceaccounts",
+	"events", "nodes", "ns",
+}
+
+// lazyKinds são pesados (secrets, events) e só ganham informer quando o box
+// deles é mostrado; até lá List vai ao polling.
+var lazyKinds = map[string]bool{"secrets": true, "events": true}
+
+// Cache atende List a partir de informers; até o primeiro sync (ou para
+// kinds sem informer, como CRDs e métricas) delega ao fallback. Cada kind
+// tem um informer do cluster todo; se o RBAC proíbe listar no cluster, o
+// kind passa a ter um informer por namespace consultado.
+type Cache struct {
+	client   kubernetes.Interface
+	fallback Backend
+
+	mu        sync.RWMutex
+	onChange  func(kind string)
+	started   bool
+	stopped   bool
+	watches   map[string]*watch // chave: kind (cluster todo) ou kind/namespace
+	forbidden map[string]bool   // chaves recusadas com Forbidden
+	shown     map[string]bool   // lazyKinds já pedidos por Watch
+	swept     time.Time         // última passada de sweepWatches
+	state     map[string]SyncState
+	polled    map[string]polledResult
+}
+
+// watch é um informer com parada própria, para trocar o do cluster todo
+// pelos de namespace sem derrubar os outros kinds.
+type watch struct {
+	kind string
+	inf  cache.SharedIndexInformer
+	stop chan struct{}
+	used time.Time // último List atendido; os de namespace expiram por idleTTL
+}
+
+// kinds sem watch (CRDs, métricas) são reaproveitados por pollTTL.
+const pollTTL = 10 * time.Second
+
+// idleTTL é quanto um informer de namespace sobrevive sem List: o dashboard
+// relista tudo a cada 10s, então passar disso quer dizer que o namespace
+// saiu do escopo.
+const idleTTL = 3 * pollTTL
+
+type polledResult struct {
+	at      time.Time
+	objs    []runtime.Object
+	metrics []PodMetrics
+	err     error
+}
+
+func NewCache(c *kubernetes.Clientset) *Cache {
+	return &Cache{
+		client:    c,
+		fallback:  NewClientBackend(c),
+		watches:   map[string]*watch{},
+		forbidden: map[string]bool{},
+		shown:     map[string]bool{},
+		state:     map[string]SyncState{},
+		polled:    map[string]polledResult{},
+	}
+}
+
+func informerFor(f informers.SharedInformerFactory, kind string) cache.SharedIndexInformer {
+	switch kind {
+	case "deploy":
+		return f.Apps().V1().Deployments().Informer()
+	case "rs":
+		return f.Apps().V1().ReplicaSets().Informer()
+	case "sts":
+		return f.Apps().V1().StatefulSets().Informer()
+	case "ds":
+		return f.Apps().V1().DaemonSets().Informer()
+	case "jobs":
+		return f.Batch().V1().Jobs().Informer()
+	case "cronjobs":
+		return f.Batch().V1().CronJobs().Informer()
+	case "pods":
+		return f.Core().V1().Pods().Informer()
+	case "svc":
+		return f.Core().V1().Services().Informer()
+	case "ingress":
+		return f.Networking().V1().Ingresses().Informer()
+	case "endpoints":
+		return f.Core().V1().Endpoints().Informer()
+	case "pvc":
+		return f.Core().V1().PersistentVolumeClaims().Informer()
+	case "pv":
+		return f.Core().V1().PersistentVolumes().Informer()
+	case "secrets":
+		return f.Core().V1().Secrets().Informer()
+	case "configmaps":
+		return f.Core().V1().ConfigMaps().Informer()
+	case "serviceaccounts":
+		return f.Core().V1().ServiceAccounts().Informer()
+	case "events":
+		return f.Core().V1().Events().Informer()
+	case "nodes":
+		return f.Core().V1().Nodes().Informer()
+	case "ns":
+		return f.Core().V1().Namespaces().Informer()
+	}
+	return nil
+}
+
+// Start inicia os watches do cluster todo (menos os lazyKinds); onChange é
+// chamado a cada evento.
+func (c *Cache) Start(onChange func(kind string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onChange = onChange
+	c.started = true
+	for _, kind := range watchedKinds {
+		if !lazyKinds[kind] || c.shown[kind] {
+			c.startWatch(kind, metav1.NamespaceAll)
+		}
+	}
+}
+
+// Watch liga os lazyKinds pedidos; os outros já estão observados.
+func (c *Cache) Watch(kinds ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, kind := range kinds {
+		if !lazyKinds[kind] || c.shown[kind] {
+			continue
+		}
+		c.shown[kind] = true
+		if c.started && !c.forbidden[kind] {
+			c.startWatch(kind, metav1.NamespaceAll)
+		}
+	}
+}
+
+func (c *Cache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	for key, w := range c.watches {
+		close(w.stop)
+		delete(c.watches, key)
+	}
+}
+
+func watchKey(kind, ns string) string {
+	if ns == metav1.NamespaceAll {
+		return kind
+	}
+	return kind + "/" + ns
+}
+
+// startWatch cria e roda o informer de kind em ns ("" = cluster todo).
+// Chamado com c.mu travado.
+func (c *Cache) startWatch(kind, ns string) *watch {
+	key := watchKey(kind, ns)
+	if w, ok := c.watches[key]; ok || c.stopped {
+		return w
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(c.client, 0, informers.WithNamespace(ns))
+	w := &watch{kind: kind, inf: informerFor(factory, kind), stop: make(chan struct{})}
+	_ = w.inf.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		c.watchError(key, w, err)
+	})
+	notify := func() {
+		c.touch(kind)
+		if c.onChange != nil {
+			c.onChange(kind)
+		}
+	}
+	_, _ = w.inf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+	c.watches[key] = w
+	go w.inf.Run(w.stop)
+	// kinds vazios não geram eventos; marca o sync inicial assim que terminar.
+	go func() {
+		if cache.WaitForCacheSync(w.stop, w.inf.HasSynced) {
+			notify()
+		}
+	}()
+	return w
+}
+
+// watchError guarda o erro do watch. Forbidden derruba o informer: no
+// cluster todo, o kind passa aos informers por namespace; nos outros casos
+// List volta ao polling, que mostra o erro no box.
+func (c *Cache) watchError(key string, w *watch, err error) {
+	c.mu.Lock()
+	st := c.state[w.kind]
+	st.Err = err
+	c.state[w.kind] = st
+	forbidden := apierrors.IsForbidden(err) && c.watches[key] == w
+	if forbidden {
+		c.forbidden[key] = true
+		delete(c.watches, key)
+		close(w.stop)
+	}
+	onChange := c.onChange
+	c.mu.Unlock()
+	if forbidden && onChange != nil {
+		onChange(w.kind)
+	}
+}
+
+// watchFor é o informer que atende kind no namespace target; nil manda List
+// ao polling.
+func (c *Cache) watchFor(kind, target string) *watch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweepWatches()
+	if !c.started || (lazyKinds[kind] && !c.shown[kind]) {
+		return nil
+	}
+	if !c.forbidden[kind] {
+		return c.startWatch(kind, metav1.NamespaceAll)
+	}
+	key := watchKey(kind, target)
+	if clusterScoped[kind] || target == metav1.NamespaceAll || c.forbidden[key] {
+		return nil
+	}
+	w := c.startWatch(kind, target)
+	if w != nil {
+		w.used = time.Now()
+	}
+	return w
+}
+
+// sweepWatches para os informers de namespace sem List há mais de idleTTL,
+// para que trocar de namespace não deixe watches abertos até o Stop. Os do
+// cluster todo ficam. Chamado com c.mu travado.
+func (c *Cache) sweepWatches() {
+	if time.Since(c.swept) < pollTTL {
+		return
+	}
+	c.swept = time.Now()
+	for key, w := range c.watches {
+		if key != w.kind && time.Since(w.used) > idleTTL {
+			close(w.stop)
+			delete(c.watches, key)
+		}
+	}
+}
+
+func (c *Cache) touch(kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state[kind] = SyncState{Watched: true, Synced: c.synced(kind), At: time.Now()}
+}
+
+// synced diz se há informer de kind e todos já sincronizaram. Chamado com
+// c.mu travado.
+func (c *Cache) synced(kind string) bool {
+	found := false
+	for _, w := range c.watches {
+		if w.kind != kind {
+			continue
+		}
+		if !w.inf.HasSynced() {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func (c *Cache) SyncState(kind string) SyncState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	watched := false
+	for _, w := range c.watches {
+		watched = watched || w.kind == kind
+	}
+	if !watched {
+		return SyncState{}
+	}
+	st := c.state[kind]
+	st.Watched = true
+	st.Synced = c.synced(kind)
+	return st
+}
+
+// List filtra labels no próprio cache; field selectors dependem dos índices
+// do apiserver, então vão ao fallback (reaproveitado por pollTTL).
+func (c *Cache) List(kind string, s Scope) ([]runtime.Object, error) {
+	target := namespaceTarget(s.Namespace)
+	var w *watch
+	if strings.TrimSpace(s.Fields) == "" && slices.Contains(watchedKinds, kind) {
+		w = c.watchFor(kind, target)
+	}
+	if w == nil {
+		res := c.poll("list/"+kind+"/"+s.key(), func() polledResult {
+			objs, err := c.fallback.List(kind, s)
+			return polledResult{objs: objs, err: err}
+		})
+		return res.objs, res.err
+	}
+	inf := w.inf
+	if !inf.HasSynced() {
+		return c.fallback.List(kind, s)
+	}
+
+	var items []interface{}
+	if target != metav1.NamespaceAll && !clusterScoped[kind] {
+		var err error
+		items, err = inf.GetIndexer().ByIndex(cache.NamespaceIndex, target)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		items = inf.GetStore().List()
+	}
+
+	objs := make([]runtime.Object, 0, len(items))
+	for _, it := range items {
+		if obj, ok := it.(runtime.Object); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return s.matchLabels(objs)
+}
+
+func (c *Cache) PodMetrics(ns string) ([]PodMetrics, error) {
+	res := c.poll("metrics/"+ns, func() polledResult {
+		items, err := c.fallback.PodMetrics(ns)
+		return polledResult{metrics: items, err: err}
+	})
+	return res.metrics, res.err
+}
+
+func (c *Cache) poll(key string, fetch func() polledResult) polledResult {
+	c.mu.RLock()
+	res, ok := c.polled[key]
+	c.mu.RUnlock()
+	if ok && time.Since(res.at) < pollTTL {
+		return res
+	}
+	res = fetch()
+	res.at = time.Now()
+	c.mu.Lock()
+	// cada combinação de escopo gera uma chave; as vencidas não voltam a ser
+	// lidas, então saem aqui em vez de acumular.
+	for k, old := range c.polled {
+		if time.Since(old.at) >= pollTTL {
+			delete(c.polled, k)
+		}
+	}
+	c.polled[key] = res
+	c.mu.Unlock()
+	return res
+}
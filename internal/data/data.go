@@ -2,31 +2,57 @@ package data
 
 import (
 	"context"
-	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
-	"ktwins/internal/theme"
-
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-const (
-	cmdTimeout     = 1200 * time.Millisecond
-	maxOutputBytes = 64 * 1024 // protege UI de outputs gigantes
+const cmdTimeout = 1200 * time.Millisecond
+
+var (
+	kubectlMu      sync.RWMutex
+	kubectlContext string
 )
 
-// Executa comandos (kubectl) com timeout e limite de saída; evita shell para reduzir overhead/injeção.
+// SetKubectlContext faz toda chamada ao kubectl usar --context name, para não
+// divergir do contexto do clientset; vazio volta ao padrão do kubectl.
+func SetKubectlContext(name string) {
+	kubectlMu.Lock()
+	defer kubectlMu.Unlock()
+	kubectlContext = name
+}
+
+// kubectlFlags são as flags globais comuns a toda chamada ao kubectl;
+// kubeContext vazio usa o contexto de SetKubectlContext.
+func kubectlFlags(timeout time.Duration, kubeContext string) []string {
+	kubectlMu.RLock()
+	defer kubectlMu.RUnlock()
+	if kubeContext == "" {
+		kubeContext = kubectlContext
+	}
+	flags := []string{"--request-timeout=" + timeout.String()}
+	if kubeContext != "" {
+		flags = append(flags, "--context", kubeContext)
+	}
+	return flags
+}
+
+// Executa comandos (kubectl) com timeout; evita shell para reduzir overhead/injeção.
 func runWithTimeout(timeout time.Duration, cmd string, args ...string) string {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	c := exec.CommandContext(ctx, cmd, args...)
 	var buf strings.Builder
-	c.Stdout = newLimitedWriter(&buf, maxOutputBytes)
-	c.Stderr = newLimitedWriter(&buf, maxOutputBytes)
+	c.Stdout = &buf
+	c.Stderr = &buf
 
 	_ = c.Run()
 
@@ -37,7 +63,7 @@ func runWithTimeout(timeout time.Duration, cmd string, args ...string) string {
 }
 
 func runKubectl(args ...string) string {
-	full := append([]string{"--request-timeout=1s"}, args...)
+	full := append(kubectlFlags(time.Second, ""), args...)
 	return runWithTimeout(cmdTimeout, "kubectl", full...)
 }
 
@@ -46,375 +72,199 @@ func RunKubectl(args ...string) string {
 	return runKubectl(args...)
 }
 
-// limitador de saída para proteger a UI
-type limitedWriter struct {
-	w     *strings.Builder
-	limit int
-}
-
-func (lw *limitedWriter) Write(p []byte) (int, error) {
-	avail := lw.limit - lw.w.Len()
-	if avail <= 0 {
-		return len(p), nil
+// listScope consulta cada namespace do escopo e junta os objetos.
+func listScope(b Backend, kind string, s Scope) ([]runtime.Object, error) {
+	if clusterScoped[kind] || len(s.Namespaces) == 0 {
+		return b.List(kind, s)
 	}
-	if len(p) > avail {
-		p = p[:avail]
-	}
-	return lw.w.Write(p)
-}
-
-func newLimitedWriter(dst *strings.Builder, limit int) *limitedWriter {
-	return &limitedWriter{w: dst, limit: limit}
-}
-
-// Contador genérico (usando client-go + kubectl para CRD)
-func count(c *kubernetes.Clientset, kind, ns string, cluster bool) int {
-	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
-	defer cancel()
-	nsTarget := strings.TrimSpace(ns)
-	if nsTarget == "" || strings.EqualFold(nsTarget, "all") {
-		nsTarget = metav1.NamespaceAll
-	}
-
-	switch kind {
-	case "deploy":
-		out, err := c.AppsV1().Deployments(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "rs":
-		out, err := c.AppsV1().ReplicaSets(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "sts":
-		out, err := c.AppsV1().StatefulSets(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
+	var objs []runtime.Object
+	for _, ns := range s.Namespaces {
+		part, err := b.List(kind, s.single(ns))
+		if err != nil {
+			return nil, err
 		}
-		return len(out.Items)
-	case "ds":
-		out, err := c.AppsV1().DaemonSets(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "jobs":
-		out, err := c.BatchV1().Jobs(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "cronjobs":
-		out, err := c.BatchV1().CronJobs(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "pods":
-		out, err := c.CoreV1().Pods(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "svc":
-		out, err := c.CoreV1().Services(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "ingress":
-		out, err := c.NetworkingV1().Ingresses(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "endpoints":
-		out, err := c.CoreV1().Endpoints(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "pvc":
-		out, err := c.CoreV1().PersistentVolumeClaims(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "secrets":
-		out, err := c.CoreV1().Secrets(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "configmaps":
-		out, err := c.CoreV1().ConfigMaps(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "serviceaccounts":
-		out, err := c.CoreV1().ServiceAccounts(nsTarget).List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "nodes":
-		out, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "pv":
-		out, err := c.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
-		if err != nil || out == nil {
-			return 0
-		}
-		return len(out.Items)
-	case "crd":
-		crdOut := runKubectl("get", "crd", "--no-headers")
-		if strings.Contains(crdOut, "No resources found") {
-			return 0
-		}
-		lines := strings.Split(strings.TrimSpace(crdOut), "\n")
-		if len(lines) == 0 || lines[0] == "" {
-			return 0
-		}
-		return len(lines)
+		objs = append(objs, part...)
 	}
-
-	return 0
+	return objs, nil
 }
 
-func BuildSummary(ns string, c *kubernetes.Clientset) string {
-	displayNS := ns
-	if displayNS == "" {
-		displayNS = "ALL"
+// Contador genérico sobre o Backend (client-go ou kubectl)
+func count(b Backend, kind string, s Scope) int {
+	objs, err := listScope(b, kind, s)
+	if err != nil {
+		return 0
 	}
-	deploy := count(c, "deploy", ns, false)
-	rs := count(c, "rs", ns, false)
-	sts := count(c, "sts", ns, false)
-	ds := count(c, "ds", ns, false)
-	jobs := count(c, "jobs", ns, false)
-	cj := count(c, "cronjobs", ns, false)
-	pods := count(c, "pods", ns, false)
-	svc := count(c, "svc", ns, false)
-	ing := count(c, "ingress", ns, false)
-	ep := count(c, "endpoints", ns, false)
-	pvc := count(c, "pvc", ns, false)
-	pv := count(c, "pv", ns, true)
-	secrets := count(c, "secrets", ns, false)
-	cm := count(c, "configmaps", ns, false)
-	sa := count(c, "serviceaccounts", ns, false)
-	nodes := count(c, "nodes", ns, true)
-	crd := count(c, "crd", ns, true)
-
-	line1 := fmt.Sprintf("%sNS%s %-10s",
-		theme.Title, theme.Reset, displayNS)
-
-	line2 := fmt.Sprintf("%sinfra%s nodes:%d crd:%d",
-		theme.Header, theme.Reset, nodes, crd)
-
-	line3 := fmt.Sprintf("%sconfig%s sec:%d cm:%d sa:%d",
-		theme.Header, theme.Reset, secrets, cm, sa)
-
-	line4 := fmt.Sprintf("%snet%s svc:%d ing:%d ep:%d",
-		theme.Header, theme.Reset, svc, ing, ep)
-
-	line5 := fmt.Sprintf("%sstorage%s pvc:%d pv:%d",
-		theme.Header, theme.Reset, pvc, pv)
-
-	line6 := fmt.Sprintf("%sworkloads%s d:%d rs:%d sts:%d ds:%d jobs:%d cj:%d",
-		theme.Header, theme.Reset, deploy, rs, sts, ds, jobs, cj)
-
-	line7 := fmt.Sprintf("%spods%s %d",
-		theme.Header, theme.Reset, pods)
-
-	return strings.Join([]string{
-		line1,
-		line2,
-		line3,
-		line4,
-		line5,
-		line6,
-		line7,
-	}, "\n")
-
+	return len(objs)
 }
 
-func BuildAlerts(ns string) string {
-	args := append([]string{"get", "pods", "--no-headers"}, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	lines := strings.Split(out, "\n")
-	var alerts strings.Builder
-	count := 0
-
-	for _, l := range lines {
-		if l == "" {
-			continue
-		}
-		f := strings.Fields(l)
-		if len(f) < 3 {
-			continue
-		}
-		name := f[0]
-		status := f[2]
-
-		switch status {
-		case "CrashLoopBackOff", "Error", "ImagePullBackOff",
-			"ErrImagePull", "Pending", "CreateContainerError":
-			alerts.WriteString(fmt.Sprintf("%s⚠ %s: %s%s\n",
-				theme.Red, name, status, theme.Reset))
-			count++
-			if count >= 5 {
-				break
-			}
-		}
-	}
-	return strings.TrimSpace(alerts.String())
+var summaryKinds = []string{
+	"deploy", "rs", "sts", "ds", "jobs", "cronjobs", "pods",
+	"svc", "ingress", "endpoints", "pvc", "pv",
+	"secrets", "configmaps", "serviceaccounts", "nodes", "crd",
 }
 
-func buildInfra() string {
-	return strings.TrimSpace(runKubectl("get", "nodes")) + "\n"
+func BuildSummary(s Scope, b Backend) Summary {
+	sum := Summary{Namespace: s.Namespace, Namespaces: s.Namespaces, Selector: s.Selector(), Counts: map[string]int{}}
+	for _, kind := range summaryKinds {
+		sum.Counts[kind] = count(b, kind, s)
+	}
+	return sum
 }
 
-func buildCRD() string {
-	return strings.TrimSpace(runKubectl("get", "crd")) + "\n"
+var alertReasons = map[string]bool{
+	"CrashLoopBackOff":     true,
+	"Error":                true,
+	"ImagePullBackOff":     true,
+	"ErrImagePull":         true,
+	"Pending":              true,
+	"CreateContainerError": true,
 }
 
-func buildConfig(ns, kind string) string {
-	args := []string{"get", kind}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	if strings.Contains(out, "No resources found") {
-		return ""
+// BuildAlerts devolve os pods em estado de alerta; Row.Status traz o motivo.
+func BuildAlerts(s Scope, b Backend) []Row {
+	pods, err := listScope(b, "pods", s)
+	if err != nil {
+		return nil
 	}
-	return out
-}
-
-func buildNetwork(ns, kind string) string {
-	args := []string{"get", kind}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	if strings.Contains(out, "No resources found") {
-		return ""
+	sortObjects(pods)
+	var alerts []Row
+	for _, row := range newTable("pods", pods, false).Rows {
+		if alertReasons[row.Status] {
+			alerts = append(alerts, row)
+		}
 	}
-	return out
+	return alerts
 }
 
-func buildStorage(ns string) string {
-	args := []string{"get", "pvc"}
-	args = append(args, NSSelector(ns, true)...)
-	pvc := runKubectl(args...)
-	if strings.Contains(pvc, "No resources found") {
-		return ""
+// buildList monta a tabela de um kind; erros ficam em Table.Err.
+func buildList(b Backend, kind string, s Scope) Table {
+	objs, err := listScope(b, kind, s)
+	if err != nil {
+		return Table{Kind: kind, Err: err}
 	}
-	return pvc
+	sortObjects(objs)
+	return newTable(kind, objs, !clusterScoped[kind] && s.multiNamespace())
 }
 
-func buildPV() string {
-	pv := runKubectl("get", "pv")
-	if strings.Contains(pv, "No resources found") {
-		return ""
-	}
-	return pv
+func BuildPods(s Scope, b Backend) Table {
+	return buildList(b, "pods", s)
 }
 
-func buildWorkloads(ns, kind string) string {
-	args := []string{"get", kind}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	if strings.Contains(out, "No resources found") {
-		return ""
+// BuildMetrics reproduz "kubectl top pods"; com seletor, só os pods do escopo.
+func BuildMetrics(s Scope, b Backend) Table {
+	var items []PodMetrics
+	for _, ns := range s.targets() {
+		part, err := b.PodMetrics(ns)
+		if err != nil {
+			// métricas são opcionais (metrics-server ausente): box vazio em vez de erro
+			return Table{Kind: "pods"}
+		}
+		items = append(items, part...)
 	}
-	return out
-}
-
-func BuildPods(ns string) string {
-	args := []string{"get", "pods"}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	if strings.Contains(out, "No resources found") {
-		return ""
+	if s.HasSelector() {
+		pods, err := listScope(b, "pods", s)
+		if err != nil {
+			return Table{Kind: "pods", Err: err}
+		}
+		inScope := map[string]bool{}
+		for _, obj := range pods {
+			if m, ok := obj.(metav1.Object); ok {
+				inScope[m.GetNamespace()+"/"+m.GetName()] = true
+			}
+		}
+		kept := make([]PodMetrics, 0, len(items))
+		for _, pm := range items {
+			if inScope[pm.Namespace+"/"+pm.Name] {
+				kept = append(kept, pm)
+			}
+		}
+		items = kept
 	}
-	return out
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+	return newMetricsTable(items, s.multiNamespace())
 }
 
-func BuildMetrics(ns string) string {
-	args := []string{"top", "pods"}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	if strings.TrimSpace(out) == "" {
-		return ""
+// BuildEvents devolve todos os eventos, do mais antigo ao mais recente.
+// Eventos quase nunca têm labels: com seletor, ficam os eventos cujo
+// involvedObject está no escopo.
+func BuildEvents(s Scope, b Backend) Table {
+	objs, err := listScope(b, "events", Scope{Namespace: s.Namespace, Namespaces: s.Namespaces})
+	if err != nil {
+		return Table{Kind: "events", Err: err}
 	}
-	lower := strings.ToLower(out)
-	if strings.Contains(lower, "error") || strings.Contains(lower, "timeout") || strings.Contains(lower, "unavailable") {
-		return ""
+	if s.HasSelector() {
+		uids := scopedUIDs(b, s)
+		kept := make([]runtime.Object, 0, len(objs))
+		for _, obj := range objs {
+			if uids[obj.(*corev1.Event).InvolvedObject.UID] {
+				kept = append(kept, obj)
+			}
+		}
+		objs = kept
 	}
-	return theme.Green + out + theme.Reset
+	sort.SliceStable(objs, func(i, j int) bool {
+		return objs[i].(*corev1.Event).CreationTimestamp.Before(&objs[j].(*corev1.Event).CreationTimestamp)
+	})
+	return newTable("events", objs, s.multiNamespace())
 }
 
-func BuildEvents(ns string) string {
-	args := []string{"get", "events", "--sort-by=.metadata.creationTimestamp"}
-	args = append(args, NSSelector(ns, true)...)
-	out := runKubectl(args...)
-	lines := strings.Split(strings.TrimSpace(out), "\n")
-	if len(lines) > 20 {
-		lines = lines[len(lines)-20:]
+// eventKinds são os kinds que costumam aparecer como involvedObject; listar
+// os 17 do resumo a cada refresh custaria um kubectl get por kind no backend
+// kubectl.
+var eventKinds = []string{"pods", "deploy", "rs", "sts", "ds", "jobs", "nodes"}
+
+// scopedUIDs reúne os UIDs dos objetos de eventKinds que o escopo alcança.
+func scopedUIDs(b Backend, s Scope) map[types.UID]bool {
+	uids := map[types.UID]bool{}
+	for _, kind := range eventKinds {
+		objs, err := listScope(b, kind, s)
+		if err != nil {
+			continue
+		}
+		for _, obj := range objs {
+			if m, ok := obj.(metav1.Object); ok {
+				uids[m.GetUID()] = true
+			}
+		}
 	}
-	return strings.TrimSpace(strings.Join(lines, "\n"))
+	return uids
 }
 
-func BuildConfigGroup(ns string) string {
-	return buildGroup(ns, []string{"secrets", "configmaps", "serviceaccounts"}, buildConfig)
+func BuildConfigGroup(s Scope, b Backend) []Table {
+	return buildGroup(b, s, []string{"secrets", "configmaps", "serviceaccounts"})
 }
 
-func BuildNetworkGroup(ns string) string {
-	return buildGroup(ns, []string{"svc", "ingress", "endpoints"}, buildNetwork)
+func BuildNetworkGroup(s Scope, b Backend) []Table {
+	return buildGroup(b, s, []string{"svc", "ingress", "endpoints"})
 }
 
-func BuildStorageGroup(ns string) string {
-	var b strings.Builder
-	if pvcOut := buildStorage(ns); pvcOut != "" {
-		fmt.Fprintf(&b, "PVC\n%s\n\n", pvcOut)
-	}
-	if pvOut := buildPV(); pvOut != "" {
-		fmt.Fprintf(&b, "PV\n%s\n\n", pvOut)
-	}
-	return strings.TrimSpace(b.String())
+func BuildStorageGroup(s Scope, b Backend) []Table {
+	return buildGroup(b, s, []string{"pvc", "pv"})
 }
 
-func BuildInfraGroup() string {
-	var b strings.Builder
-	if nodes := strings.TrimSpace(buildInfra()); nodes != "" {
-		fmt.Fprintf(&b, "NODES\n%s\n\n", nodes)
-	}
-	if crd := strings.TrimSpace(buildCRD()); crd != "" {
-		fmt.Fprintf(&b, "CRDs\n%s\n\n", crd)
-	}
-	return strings.TrimSpace(b.String())
+// BuildInfraGroup lista só kinds de cluster; os seletores valem, o namespace não.
+func BuildInfraGroup(s Scope, b Backend) []Table {
+	return buildGroup(b, Scope{Labels: s.Labels, Fields: s.Fields}, []string{"nodes", "crd"})
 }
 
-func BuildWorkloadsGroup(ns string) string {
-	var b strings.Builder
-	for _, kind := range []string{"deploy", "rs", "sts", "ds", "jobs", "cronjobs"} {
-		if out := buildWorkloads(ns, kind); out != "" {
-			fmt.Fprintf(&b, "%s\n%s\n\n", strings.ToUpper(kind), clampLines(out, 20))
-		}
-	}
-	return strings.TrimSpace(b.String())
+func BuildWorkloadsGroup(s Scope, b Backend) []Table {
+	return buildGroup(b, s, []string{"deploy", "rs", "sts", "ds", "jobs", "cronjobs"})
 }
 
-func buildGroup(ns string, kinds []string, fetch func(ns, kind string) string) string {
-	var b strings.Builder
+// buildGroup omite kinds sem objetos, como o "No resources found" do kubectl.
+func buildGroup(b Backend, s Scope, kinds []string) []Table {
+	var tables []Table
 	for _, kind := range kinds {
-		if out := fetch(ns, kind); out != "" {
-			fmt.Fprintf(&b, "%s\n%s\n\n", strings.ToUpper(kind), out)
+		if t := buildList(b, kind, s); t.Err != nil || len(t.Rows) > 0 {
+			tables = append(tables, t)
 		}
 	}
-	return strings.TrimSpace(b.String())
+	return tables
 }
 
 func NSSelector(ns string, allowAll bool) []string {
@@ -431,33 +281,22 @@ func NSSelector(ns string, allowAll bool) []string {
 	return []string{"-n", trimmed}
 }
 
-func BuildNamespaces() (string, []string) {
-	out := runKubectl("get", "ns", "--no-headers")
-	lines := strings.Split(strings.TrimSpace(out), "\n")
-	var b strings.Builder
-	names := []string{""}
-	b.WriteString("0) ALL\n")
-	idx := 1
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-		fields := strings.Fields(line)
-		if len(fields) == 0 {
-			continue
-		}
-		name := fields[0]
-		names = append(names, name)
-		fmt.Fprintf(&b, "%d) %s\n", idx, name)
-		idx++
-	}
-	return b.String(), names
+// BuildNamespaces lista os namespaces (NAME STATUS AGE) em ordem alfabética.
+// Fica fora dos seletores para a navegação entre namespaces continuar possível.
+func BuildNamespaces(b Backend) Table {
+	return buildList(b, "ns", Scope{})
 }
 
-func clampLines(s string, maxLines int) string {
-	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
-	if len(lines) <= maxLines {
-		return strings.Join(lines, "\n")
-	}
-	return strings.Join(lines[:maxLines], "\n")
+func sortObjects(objs []runtime.Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, _ := objs[i].(metav1.Object)
+		b, _ := objs[j].(metav1.Object)
+		if a == nil || b == nil {
+			return false
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
 }
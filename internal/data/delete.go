@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+// Políticas de propagação do delete (--cascade do kubectl).
+var Propagations = []string{"background", "foreground", "orphan"}
+
+var propagationPolicies = map[string]metav1.DeletionPropagation{
+	"background": metav1.DeletePropagationBackground,
+	"foreground": metav1.DeletePropagationForeground,
+	"orphan":     metav1.DeletePropagationOrphan,
+}
+
+// DeleteOptions são as opções do delete, como no "kubectl delete".
+type DeleteOptions struct {
+	Propagation string // background, foreground ou orphan
+	GracePeriod int    // segundos; negativo = o prazo do próprio objeto
+	Force       bool   // remove já, sem esperar o kubelet (pods presos em Terminating)
+}
+
+// Delete apaga o objeto pela sessão de cfg, sem esperar a remoção terminar
+// (como "kubectl delete --wait=false"). Force é prazo 0, como o kubectl
+// exige para --force.
+func Delete(ctx context.Context, cfg *rest.Config, kind, name, namespace string, o DeleteOptions) error {
+	res, err := objectResource(cfg, kind, namespace)
+	if err != nil {
+		return err
+	}
+	opts := metav1.DeleteOptions{}
+	if policy, ok := propagationPolicies[o.Propagation]; ok {
+		opts.PropagationPolicy = &policy
+	}
+	grace := int64(o.GracePeriod)
+	if o.Force {
+		grace = 0
+	}
+	if grace >= 0 {
+		opts.GracePeriodSeconds = &grace
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	return res.Delete(ctx, name, opts)
+}
+
+// OwnedObject é um dependente encontrado pelas ownerReferences; Depth 1 é
+// filho direto do objeto apagado.
+type OwnedObject struct {
+	Kind, Name string
+	Depth      int
+}
+
+// ownedKinds são os kinds que costumam ter dono (controllers, operadores).
+var ownedKinds = []string{"deploy", "rs", "sts", "ds", "jobs", "cronjobs", "pods", "svc", "endpoints", "ingress", "pvc", "configmaps", "secrets", "serviceaccounts"}
+
+// OwnedObjects segue as ownerReferences a partir de uid e devolve os
+// dependentes diretos e indiretos em ordem de árvore (deploy → rs → pods).
+// Objetos com dono fora do namespace (ex.: Node) são buscados em todos.
+// Kinds que o RBAC não deixa listar ficam de fora e voltam em forbidden,
+// para a lista ser mostrada como parcial em vez de sumir.
+func OwnedObjects(b Backend, kind, namespace string, uid types.UID) (owned []OwnedObject, forbidden []string, err error) {
+	if kind == "ns" || uid == "" {
+		return nil, nil, nil // o namespace leva tudo o que tem dentro
+	}
+	if clusterScoped[kind] {
+		namespace = ""
+	}
+	type child struct {
+		kind, name string
+		uid        types.UID
+	}
+	children := map[types.UID][]child{}
+	for _, k := range ownedKinds {
+		objs, err := b.List(k, Scope{Namespace: namespace})
+		if apierrors.IsForbidden(err) {
+			forbidden = append(forbidden, k)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, obj := range objs {
+			m, err := meta.Accessor(obj)
+			if err != nil {
+				continue
+			}
+			for _, ref := range m.GetOwnerReferences() {
+				children[ref.UID] = append(children[ref.UID], child{k, m.GetName(), m.GetUID()})
+			}
+		}
+	}
+	seen := map[types.UID]bool{uid: true}
+	var walk func(owner types.UID, depth int)
+	walk = func(owner types.UID, depth int) {
+		for _, c := range children[owner] {
+			if seen[c.uid] {
+				continue
+			}
+			seen[c.uid] = true
+			owned = append(owned, OwnedObject{Kind: c.kind, Name: c.name, Depth: depth})
+			walk(c.uid, depth+1)
+		}
+	}
+	walk(uid, 1)
+	return owned, forbidden, nil
+}
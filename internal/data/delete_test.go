@@ -0,0 +1,64 @@
+package data
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestOwnedObjects(t *testing.T) {
+	meta := func(name string, uid, owner types.UID) metav1.ObjectMeta {
+		m := metav1.ObjectMeta{Name: name, UID: uid}
+		if owner != "" {
+			m.OwnerReferences = []metav1.OwnerReference{{UID: owner}}
+		}
+		return m
+	}
+	objs := map[string][]runtime.Object{
+		"rs": {&appsv1.ReplicaSet{ObjectMeta: meta("web-1", "rs-1", "deploy-web")}},
+		"pods": {
+			&corev1.Pod{ObjectMeta: meta("web-1-a", "pod-a", "rs-1")},
+			&corev1.Pod{ObjectMeta: meta("api-1-a", "pod-b", "rs-api")},
+		},
+	}
+	forbiddenErr := func(resource string) error {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", errors.New("rbac"))
+	}
+	tests := []struct {
+		name          string
+		errs          map[string]error
+		want          []OwnedObject
+		wantForbidden []string
+		wantErr       bool
+	}{
+		{"tree", nil, []OwnedObject{{"rs", "web-1", 1}, {"pods", "web-1-a", 2}}, nil, false},
+		{
+			"forbidden kinds are skipped",
+			map[string]error{"secrets": forbiddenErr("secrets"), "serviceaccounts": forbiddenErr("serviceaccounts")},
+			[]OwnedObject{{"rs", "web-1", 1}, {"pods", "web-1-a", 2}},
+			[]string{"secrets", "serviceaccounts"},
+			false,
+		},
+		{"other errors abort", map[string]error{"pods": errors.New("timeout")}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &listBackend{objs: objs, errs: tt.errs}
+			got, forbidden, err := OwnedObjects(b, "deploy", "default", "deploy-web")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(forbidden, tt.wantForbidden) {
+				t.Errorf("got (%+v, %v), want (%+v, %v)", got, forbidden, tt.want, tt.wantForbidden)
+			}
+		})
+	}
+}
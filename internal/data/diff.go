@@ -0,0 +1,88 @@
+package data
+
+// DiffOp diz se a linha do diff é comum, só do lado antigo ou só do novo.
+type DiffOp int
+
+const (
+	DiffSame DiffOp = iota
+	DiffDel
+	DiffAdd
+)
+
+// DiffLine é uma linha de DiffLines.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// maxDiffCells limita a tabela da LCS; acima disso o miolo que mudou sai
+// como remoção seguida de adição.
+const maxDiffCells = 4_000_000
+
+// DiffLines compara a e b linha a linha (LCS), depois de separar o começo e o
+// fim em comum, que é quase tudo numa edição de manifesto.
+func DiffLines(a, b []string) []DiffLine {
+	var out []DiffLine
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		out = append(out, DiffLine{DiffSame, a[pre]})
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	out = append(out, diffMiddle(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, l := range a[len(a)-suf:] {
+		out = append(out, DiffLine{DiffSame, l})
+	}
+	return out
+}
+
+func diffMiddle(a, b []string) []DiffLine {
+	var out []DiffLine
+	if len(a)*len(b) > maxDiffCells {
+		for _, l := range a {
+			out = append(out, DiffLine{DiffDel, l})
+		}
+		for _, l := range b {
+			out = append(out, DiffLine{DiffAdd, l})
+		}
+		return out
+	}
+	// lcs[i][j] = tamanho da LCS de a[i:] e b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffLine{DiffSame, a[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{DiffDel, a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{DiffAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, DiffLine{DiffDel, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, DiffLine{DiffAdd, b[j]})
+	}
+	return out
+}
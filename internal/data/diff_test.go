@@ -0,0 +1,79 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// formatDiff escreve o diff como " x", "-x" e "+x", para comparar fácil.
+func formatDiff(lines []DiffLine) []string {
+	var out []string
+	for _, l := range lines {
+		out = append(out, string(" -+"[l.Op])+l.Text)
+	}
+	return out
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{"equal", "a b c", "a b c", []string{" a", " b", " c"}},
+		{"both empty", "", "", nil},
+		{"added", "", "a b", []string{"+a", "+b"}},
+		{"removed", "a b", "", []string{"-a", "-b"}},
+		{"changed middle", "a b c", "a x c", []string{" a", "-b", "+x", " c"}},
+		{"insert", "a c", "a b c", []string{" a", "+b", " c"}},
+		{"delete at end", "a b c", "a b", []string{" a", " b", "-c"}},
+		{"moved line", "a b c d", "a c d b", []string{" a", "-b", " c", " d", "+b"}},
+		{"replica change", "spec: replicas: 2 image: web:1", "spec: replicas: 3 image: web:2",
+			[]string{" spec:", " replicas:", "-2", "+3", " image:", "-web:1", "+web:2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDiff(DiffLines(strings.Fields(tt.a), strings.Fields(tt.b)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDiffLinesSides confere que o diff reconstrói os dois lados, inclusive
+// acima de maxDiffCells, onde o miolo vira remoção seguida de adição.
+func TestDiffLinesSides(t *testing.T) {
+	big := func(prefix string, n int) []string {
+		out := []string{"head"}
+		for i := range n {
+			out = append(out, fmt.Sprintf("%s%d", prefix, i))
+		}
+		return append(out, "tail")
+	}
+	tests := []struct {
+		name string
+		a, b []string
+	}{
+		{"small", []string{"a", "b", "c", "d"}, []string{"b", "x", "d", "e"}},
+		{"over maxDiffCells", big("a", 2100), big("b", 2100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a, b []string
+			for _, l := range DiffLines(tt.a, tt.b) {
+				if l.Op != DiffAdd {
+					a = append(a, l.Text)
+				}
+				if l.Op != DiffDel {
+					b = append(b, l.Text)
+				}
+			}
+			if !slices.Equal(a, tt.a) || !slices.Equal(b, tt.b) {
+				t.Error("diff does not rebuild both sides")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestYAML lê o objeto em YAML como o "kubectl edit" o mostraria, para
+// ser editado e devolvido com UpdateManifest.
+func ManifestYAML(ctx context.Context, cfg *rest.Config, kind, name, namespace string) ([]byte, error) {
+	obj, err := GetObject(ctx, cfg, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(obj.Object)
+}
+
+// UpdateManifest envia o YAML editado com um Update; com dryRun só o
+// apiserver valida (DryRunAll) e nada muda. O resourceVersion do arquivo vai
+// junto, então uma mudança no meio do caminho volta como conflito 409.
+// Devolve o objeto resultante em JSON.
+func UpdateManifest(ctx context.Context, cfg *rest.Config, kind, name, namespace string, manifest []byte, dryRun bool) ([]byte, error) {
+	raw, err := yaml.YAMLToJSON(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("YAML inválido: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+	if obj.GetName() != name {
+		return nil, fmt.Errorf("o nome não pode mudar na edição (%q → %q)", name, obj.GetName())
+	}
+	res, err := objectResource(cfg, kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	opts := metav1.UpdateOptions{}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	result, err := res.Update(ctx, obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.MarshalJSON()
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// Shells são tentados em ordem por ExecShell.
+var Shells = []string{"bash", "sh"}
+
+// ExecStreams é o terminal ligado à sessão; Sizes repassa o tamanho da janela
+// (nil = sem resize).
+type ExecStreams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Sizes  remotecommand.TerminalSizeQueue
+}
+
+// Exec roda command no container com TTY, como "kubectl exec -it". Tenta
+// WebSocket e cai para SPDY em apiservers que não fazem upgrade para ele.
+func Exec(ctx context.Context, cfg *rest.Config, c kubernetes.Interface, t LogTarget, command []string, s ExecStreams) error {
+	req := c.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(t.Namespace).Name(t.Pod).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: t.Container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true, // com TTY o stderr vem junto no stdout
+		}, scheme.ParameterCodec)
+	ws, err := remotecommand.NewWebSocketExecutor(cfg, "GET", req.URL().String())
+	if err != nil {
+		return err
+	}
+	spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	exec, err := remotecommand.NewFallbackExecutor(ws, spdy, httpstream.IsUpgradeFailure)
+	if err != nil {
+		return err
+	}
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             s.Stdin,
+		Stdout:            s.Stdout,
+		Tty:               true,
+		TerminalSizeQueue: s.Sizes,
+	})
+}
+
+// ExecShell abre um shell interativo no container: bash e, se a imagem não
+// tiver, sh. Devolve o shell usado. Um exit do próprio shell com código
+// diferente de zero não é erro.
+func ExecShell(ctx context.Context, cfg *rest.Config, c kubernetes.Interface, t LogTarget, s ExecStreams) (string, error) {
+	pump := pumpInput(s.Stdin)
+	defer close(pump.stop)
+	var err error
+	for _, shell := range Shells {
+		in := &sessionInput{pump: pump, done: make(chan struct{})}
+		streams := s
+		streams.Stdin = in
+		err = Exec(ctx, cfg, c, t, []string{shell}, streams)
+		close(in.done) // solta a goroutine de stdin do remotecommand sem perder tecla
+		var exit utilexec.ExitError
+		switch {
+		case err == nil:
+			return shell, nil
+		case in.n.Load() == 0 && shellMissing(err):
+			continue // nada foi digitado: o shell nem chegou a rodar
+		case errors.As(err, &exit):
+			return shell, nil
+		}
+		return shell, err
+	}
+	return "", err
+}
+
+// shellMissing reconhece o erro do runtime quando o binário não existe na
+// imagem: vem como erro da API (containerd) ou como exit 126/127.
+func shellMissing(err error) bool {
+	msg := err.Error()
+	if strings.Contains(msg, "executable file not found") || strings.Contains(msg, "no such file or directory") {
+		return true
+	}
+	var exit utilexec.ExitError
+	return errors.As(err, &exit) && (exit.ExitStatus() == 126 || exit.ExitStatus() == 127)
+}
+
+// inputPump lê o terminal numa goroutine só. O remotecommand deixa a leitura
+// de stdin pendurada quando a sessão acaba; com a bomba, o que chega depois
+// fica para a próxima tentativa em vez de sumir.
+type inputPump struct {
+	ch   chan []byte
+	stop chan struct{}
+}
+
+func pumpInput(r io.Reader) *inputPump {
+	p := &inputPump{ch: make(chan []byte), stop: make(chan struct{})}
+	go func() {
+		defer close(p.ch)
+		buf := make([]byte, 4096)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				select {
+				case p.ch <- append([]byte(nil), buf[:n]...):
+				case <-p.stop:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// sessionInput é o stdin de uma tentativa; depois de done devolve EOF. n
+// conta o que foi repassado ao container.
+type sessionInput struct {
+	pump    *inputPump
+	done    chan struct{}
+	pending []byte
+	n       atomic.Int64
+}
+
+func (s *sessionInput) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		select {
+		case b, ok := <-s.pump.ch:
+			if !ok {
+				return 0, io.EOF
+			}
+			s.pending = b
+		case <-s.done:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	s.n.Add(int64(n))
+	return n, nil
+}
@@ -0,0 +1,105 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Filter seleciona linhas de uma Table por texto, regex ou label.
+// A forma é escolhida pela expressão:
+//   - com '=' ou '!=' válidos como seletor ("app=web", "tier!=db,env=prod"): labels;
+//   - entre barras ("/^api-.*-v2/") ou com o prefixo "re:": regex sem
+//     distinção de maiúsculas sobre as colunas, caindo para substring do
+//     padrão enquanto a regex é inválida (digitação incompleta);
+//   - demais casos: substring literal sem distinção de maiúsculas, então
+//     "nginx-1.2" ou "app(v2)" valem como digitados.
+type Filter struct {
+	expr     string
+	substr   string // em minúsculas
+	re       *regexp.Regexp
+	selector labels.Selector
+}
+
+// ParseFilter interpreta a expressão; vazia é um filtro que aceita tudo.
+func ParseFilter(expr string) Filter {
+	expr = strings.TrimSpace(expr)
+	f := Filter{expr: expr}
+	if expr == "" {
+		return f
+	}
+	if strings.Contains(expr, "=") {
+		if sel, err := labels.Parse(expr); err == nil {
+			f.selector = sel
+			return f
+		}
+	}
+	pattern, isRegex := regexPattern(expr)
+	if !isRegex {
+		f.substr = strings.ToLower(expr)
+		return f
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+	}
+	f.re = re
+	return f
+}
+
+// regexPattern reconhece a sintaxe explícita de regex: /padrão/ ou re:padrão.
+func regexPattern(expr string) (string, bool) {
+	if pattern, ok := strings.CutPrefix(expr, "re:"); ok {
+		return pattern, true
+	}
+	if len(expr) >= 2 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
+		return expr[1 : len(expr)-1], true
+	}
+	return "", false
+}
+
+func (f Filter) Empty() bool {
+	return f.expr == ""
+}
+
+func (f Filter) String() string {
+	return f.expr
+}
+
+// Match diz se a linha passa no filtro.
+func (f Filter) Match(r *Row) bool {
+	switch {
+	case f.selector != nil:
+		return f.selector.Matches(labels.Set(r.Labels))
+	case f.re != nil:
+		for _, c := range r.Columns {
+			if f.re.MatchString(c) {
+				return true
+			}
+		}
+		return false
+	case f.substr != "":
+		for _, c := range r.Columns {
+			if strings.Contains(strings.ToLower(c), f.substr) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FilterRows mantém em t só as linhas aceitas por f.
+func FilterRows(t *Table, f Filter) {
+	if f.Empty() {
+		return
+	}
+	kept := t.Rows[:0:0]
+	for i := range t.Rows {
+		if f.Match(&t.Rows[i]) {
+			kept = append(kept, t.Rows[i])
+		}
+	}
+	t.Rows = kept
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	row := Row{
+		Name:    "nginx-1.2-abc",
+		Labels:  map[string]string{"app": "web", "tier": "front"},
+		Columns: []string{"nginx-1.2-abc", "1/1", "Running"},
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"nginx", true},
+		{"NGINX", true},
+		{"running", true},
+		{"nginx-1.2", true},
+		{"nginx-102", false}, // o ponto é literal fora de /re/
+		{"(", false},
+		{"a.b", false},
+		{"/^nginx-\\d/", true},
+		{"/^abc/", false},
+		{"re:RUN+ING", true},
+		{"re:^1/1$", true},
+		{"/(/", false}, // regex inválida vira substring do padrão
+		{"re:(", false},
+		{"app=web", true},
+		{"app=api", false},
+		{"tier!=db,app=web", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if got := ParseFilter(tt.expr).Match(&row); got != tt.want {
+				t.Errorf("ParseFilter(%q).Match = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalidRegexFallsBackToSubstring(t *testing.T) {
+	row := Row{Columns: []string{"a(b"}}
+	if !ParseFilter("re:a(b").Match(&row) {
+		t.Error("incomplete regex should match its literal text")
+	}
+}
+
+func TestParseFilterEmpty(t *testing.T) {
+	for _, expr := range []string{"", "   "} {
+		f := ParseFilter(expr)
+		if !f.Empty() {
+			t.Errorf("ParseFilter(%q).Empty() = false", expr)
+		}
+	}
+	if got := ParseFilter("  app=web ").String(); got != "app=web" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	rows := []Row{
+		{Name: "api", Columns: []string{"api", "Running"}},
+		{Name: "web", Columns: []string{"web", "Failed"}},
+		{Name: "api-v2", Columns: []string{"api-v2", "Running"}},
+	}
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"api", "web", "api-v2"}},
+		{"api", []string{"api", "api-v2"}},
+		{"failed", []string{"web"}},
+		{"/-v\\d$/", []string{"api-v2"}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			table := Table{Rows: slices.Clone(rows)}
+			FilterRows(&table, ParseFilter(tt.expr))
+			var got []string
+			for _, r := range table.Rows {
+				got = append(got, r.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRowsKeepsSource(t *testing.T) {
+	rows := []Row{{Name: "a", Columns: []string{"a"}}, {Name: "b", Columns: []string{"b"}}}
+	table := Table{Rows: rows}
+	FilterRows(&table, ParseFilter("b"))
+	if rows[0].Name != "a" || rows[1].Name != "b" {
+		t.Errorf("FilterRows changed the source slice: %v", rows)
+	}
+}
@@ -0,0 +1,157 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogLevel é o nível de severidade reconhecido numa linha de log.
+type LogLevel int
+
+const (
+	LevelNone LogLevel = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// LogEntry é uma linha de log estruturada (JSON) já separada nos campos
+// que o modal alinha; Extra traz os demais como "chave=valor", em ordem.
+type LogEntry struct {
+	Time  string
+	Level LogLevel
+	Label string // nível como veio na linha
+	Msg   string
+	Extra []string
+}
+
+var (
+	timeKeys  = []string{"time", "ts", "timestamp", "@timestamp", "t"}
+	levelKeys = []string{"level", "lvl", "severity", "@level", "loglevel"}
+	msgKeys   = []string{"msg", "message", "@message", "log"}
+)
+
+// ParseJSONLog reconhece linhas JSON dos loggers comuns (zap, logrus, slog,
+// bunyan, ...); ok é falso para qualquer outra coisa.
+func ParseJSONLog(text string) (entry LogEntry, ok bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return entry, false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(text), &fields); err != nil {
+		return entry, false
+	}
+	if v, key := pickField(fields, timeKeys); key != "" {
+		entry.Time = formatLogTime(v)
+		delete(fields, key)
+	}
+	if v, key := pickField(fields, levelKeys); key != "" {
+		entry.Label = fmt.Sprint(v)
+		entry.Level = levelOf(v)
+		delete(fields, key)
+	}
+	if v, key := pickField(fields, msgKeys); key != "" {
+		entry.Msg = fmt.Sprint(v)
+		delete(fields, key)
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry.Extra = append(entry.Extra, k+"="+jsonValue(fields[k]))
+	}
+	return entry, true
+}
+
+func pickField(fields map[string]any, keys []string) (any, string) {
+	for _, k := range keys {
+		if v, ok := fields[k]; ok {
+			return v, k
+		}
+	}
+	return nil, ""
+}
+
+// formatLogTime normaliza epochs numéricos (zap usa segundos com fração).
+func formatLogTime(v any) string {
+	if f, ok := v.(float64); ok {
+		ms := int64(f)
+		if f < 1e12 { // segundos, com fração
+			ms = int64(math.Round(f * 1000))
+		}
+		return time.UnixMilli(ms).UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+	return fmt.Sprint(v)
+}
+
+func jsonValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// levelOf aceita nomes ("warn", "ERROR") e os níveis numéricos do bunyan/pino.
+func levelOf(v any) LogLevel {
+	if f, ok := v.(float64); ok {
+		switch {
+		case f >= 50:
+			return LevelError
+		case f >= 40:
+			return LevelWarn
+		case f >= 30:
+			return LevelInfo
+		}
+		return LevelDebug
+	}
+	switch strings.ToLower(fmt.Sprint(v)) {
+	case "error", "err", "fatal", "panic", "critical", "crit", "alert", "emergency", "dpanic":
+		return LevelError
+	case "warn", "warning":
+		return LevelWarn
+	case "info", "notice", "information":
+		return LevelInfo
+	case "debug", "trace":
+		return LevelDebug
+	}
+	return LevelNone
+}
+
+var (
+	levelWord = regexp.MustCompile(`\b(ERROR|ERR|FATAL|PANIC|CRITICAL|WARN|WARNING|INFO|DEBUG|TRACE)\b|level=(\w+)`)
+	klogLevel = regexp.MustCompile(`^([EWIF])\d{4} `) // E0612 15:04:05.000000 ...
+)
+
+// DetectLevel acha o nível numa linha de texto: palavra em maiúsculas,
+// "level=..." (logfmt) ou o prefixo do klog.
+func DetectLevel(text string) LogLevel {
+	if m := klogLevel.FindStringSubmatch(text); m != nil {
+		switch m[1] {
+		case "E", "F":
+			return LevelError
+		case "W":
+			return LevelWarn
+		}
+		return LevelInfo
+	}
+	if m := levelWord.FindStringSubmatch(text); m != nil {
+		if m[2] != "" {
+			return levelOf(m[2])
+		}
+		return levelOf(m[1])
+	}
+	return LevelNone
+}
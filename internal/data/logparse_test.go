@@ -0,0 +1,87 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseJSONLog(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		ok   bool
+		want LogEntry
+	}{
+		{
+			name: "zap",
+			text: `{"level":"info","ts":1764511500.123,"msg":"started","port":8080}`,
+			ok:   true,
+			want: LogEntry{Time: "2025-11-30T14:05:00.123Z", Level: LevelInfo, Label: "info", Msg: "started", Extra: []string{"port=8080"}},
+		},
+		{
+			name: "logrus",
+			text: `{"time":"2025-11-30T14:05:00Z","level":"warning","msg":"slow query","table":"users"}`,
+			ok:   true,
+			want: LogEntry{Time: "2025-11-30T14:05:00Z", Level: LevelWarn, Label: "warning", Msg: "slow query", Extra: []string{"table=users"}},
+		},
+		{
+			name: "bunyan numeric level and epoch millis",
+			text: `{"time":1764511500123,"level":50,"msg":"boom","err":{"code":1}}`,
+			ok:   true,
+			want: LogEntry{Time: "2025-11-30T14:05:00.123Z", Level: LevelError, Label: "50", Msg: "boom", Extra: []string{`err={"code":1}`}},
+		},
+		{
+			name: "extras sorted",
+			text: ` {"message":"hi","b":true,"a":"x"} `,
+			ok:   true,
+			want: LogEntry{Msg: "hi", Extra: []string{"a=x", "b=true"}},
+		},
+		{name: "plain text", text: "ERROR connection refused"},
+		{name: "invalid json", text: `{"level":"info",}`},
+		{name: "json array", text: `[1,2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseJSONLog(tt.text)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.Time != tt.want.Time || got.Level != tt.want.Level || got.Label != tt.want.Label ||
+				got.Msg != tt.want.Msg || !slices.Equal(got.Extra, tt.want.Extra) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLevel(t *testing.T) {
+	tests := []struct {
+		text string
+		want LogLevel
+	}{
+		{"E0612 15:04:05.000000       1 reflector.go:138] failed", LevelError},
+		{"F0612 15:04:05.000000       1 main.go:10] fatal", LevelError},
+		{"W0612 15:04:05.000000       1 warnings.go:70] deprecated", LevelWarn},
+		{"I0612 15:04:05.000000       1 server.go:42] serving", LevelInfo},
+		{"2025/11/30 14:05:00 ERROR connection refused", LevelError},
+		{"[WARN] disk almost full", LevelWarn},
+		{"INFO started", LevelInfo},
+		{"DEBUG cache miss", LevelDebug},
+		{`time=2025-11-30T14:05:00Z level=warn msg="slow"`, LevelWarn},
+		{"level=debug msg=tick", LevelDebug},
+		{"no errors here", LevelNone},
+		{"an error in lower case", LevelNone},
+		{"INFORMATION", LevelNone},
+		{"", LevelNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := DetectLevel(tt.text); got != tt.want {
+				t.Errorf("DetectLevel(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
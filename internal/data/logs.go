@@ -0,0 +1,326 @@
+package data
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// LogLine é uma linha de log com o timestamp do kubelet (--timestamps);
+// Time fica zero se a linha não trouxer timestamp. Source é o pod/container
+// de origem quando vários streams são juntados.
+type LogLine struct {
+	Time   time.Time
+	Text   string
+	Source string
+}
+
+// LogTarget identifica o container cujo log é seguido.
+type LogTarget struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// LogOptions ajusta o stream: por onde começar (Since, como --since-time; ou
+// as últimas Tail linhas; com ambos zerados, o log inteiro da instância) e
+// se é a instância anterior do container (--previous), que já terminou e não
+// tem follow.
+type LogOptions struct {
+	Tail     int64
+	Since    time.Time
+	Previous bool
+}
+
+const maxLogLine = 1024 * 1024
+
+// FollowLogs segue o log do container pela API (GetLogs com Follow) e chama
+// emit a cada linha, começando como o indica; resume não zero retoma dali um
+// stream que caiu. Devolve quando o stream termina ou ctx é cancelado.
+func FollowLogs(ctx context.Context, c kubernetes.Interface, t LogTarget, o LogOptions, resume time.Time, emit func(LogLine)) error {
+	opts := podLogOptions(t, o, resume)
+	opts.Follow, opts.Timestamps = !o.Previous, true
+	stream, err := c.CoreV1().Pods(t.Namespace).GetLogs(t.Pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	sc := bufio.NewScanner(stream)
+	sc.Buffer(make([]byte, 64*1024), maxLogLine)
+	for sc.Scan() {
+		emit(parseLogLine(sc.Text()))
+	}
+	return sc.Err()
+}
+
+func podLogOptions(t LogTarget, o LogOptions, resume time.Time) *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{Container: t.Container, Previous: o.Previous}
+	since := o.Since
+	if !resume.IsZero() {
+		since = resume
+	}
+	switch {
+	case !since.IsZero():
+		st := metav1.NewTime(since)
+		opts.SinceTime = &st
+	case o.Tail > 0:
+		opts.TailLines = &o.Tail
+	}
+	return opts
+}
+
+// WriteLogs copia para w o log do container no recorte de o, de uma vez e sem
+// follow, como "kubectl logs" gravaria; timestamps prefixa cada linha.
+func WriteLogs(ctx context.Context, c kubernetes.Interface, t LogTarget, o LogOptions, timestamps bool, w io.Writer) error {
+	opts := podLogOptions(t, o, time.Time{})
+	opts.Timestamps = timestamps
+	stream, err := c.CoreV1().Pods(t.Namespace).GetLogs(t.Pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(w, stream)
+	return err
+}
+
+// WriteLogBundle grava em w um tar.gz com o log de cada alvo em
+// <pod>/<container>.log, mais <pod>/<container>.previous.log quando há
+// instância anterior. Falhas de um container (ainda sem log, por exemplo)
+// não interrompem o pacote: vão para errors.txt.
+func WriteLogBundle(ctx context.Context, c kubernetes.Interface, targets []LogTarget, o LogOptions, timestamps bool, w io.Writer) error {
+	// o tar precisa do tamanho antes do conteúdo; cada log passa por um
+	// arquivo temporário para não ficar inteiro em memória
+	tmp, err := os.CreateTemp("", "ktwins-log-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	add := func(name string, r io.Reader, size int64) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: size, ModTime: now}); err != nil {
+			return err
+		}
+		_, err := io.Copy(tw, r)
+		return err
+	}
+	var errs []string
+	for _, t := range targets {
+		for _, previous := range []bool{false, true} {
+			name := t.Pod + "/" + t.Container + ".log"
+			if previous {
+				name = t.Pod + "/" + t.Container + ".previous.log"
+			}
+			if err := tmp.Truncate(0); err != nil {
+				return err
+			}
+			if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			opts := o
+			opts.Previous = previous
+			if err := WriteLogs(ctx, c, t, opts, timestamps, tmp); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// sem instância anterior é o caso comum, não um erro
+				if !previous {
+					errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+				}
+				continue
+			}
+			size, err := tmp.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := add(name, tmp, size); err != nil {
+				return err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		text := strings.Join(errs, "\n") + "\n"
+		if err := add("errors.txt", strings.NewReader(text), int64(len(text))); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func parseLogLine(s string) LogLine {
+	if ts, rest, ok := strings.Cut(s, " "); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return LogLine{Time: t, Text: rest}
+		}
+	}
+	return LogLine{Text: s}
+}
+
+// Container descreve um container do pod para a escolha de logs.
+type Container struct {
+	Name     string
+	Type     string // "init", "" (comum) ou "ephemeral"
+	State    string // Running, CrashLoopBackOff, Completed...
+	Restarts int32
+	Previous bool // há instância anterior para --previous
+}
+
+// PodContainers lista init, comuns e efêmeros, nessa ordem, e o container
+// padrão como o kubectl escolhe: a anotação
+// kubectl.kubernetes.io/default-container ou o primeiro comum.
+func PodContainers(ctx context.Context, c kubernetes.Interface, namespace, pod string) ([]Container, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	p, err := c.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, list := range [][]corev1.ContainerStatus{p.Status.InitContainerStatuses, p.Status.ContainerStatuses, p.Status.EphemeralContainerStatuses} {
+		for _, st := range list {
+			statuses[st.Name] = st
+		}
+	}
+	var out []Container
+	add := func(name, typ string) {
+		st, ok := statuses[name]
+		c := Container{Name: name, Type: typ}
+		if ok {
+			c.State = containerState(st.State)
+			c.Restarts = st.RestartCount
+			c.Previous = st.RestartCount > 0 || st.LastTerminationState.Terminated != nil
+		}
+		out = append(out, c)
+	}
+	for _, ct := range p.Spec.InitContainers {
+		add(ct.Name, "init")
+	}
+	for _, ct := range p.Spec.Containers {
+		add(ct.Name, "")
+	}
+	for _, ct := range p.Spec.EphemeralContainers {
+		add(ct.Name, "ephemeral")
+	}
+
+	def := p.Annotations["kubectl.kubernetes.io/default-container"]
+	if def == "" && len(p.Spec.Containers) > 0 {
+		def = p.Spec.Containers[0].Name
+	}
+	return out, def, nil
+}
+
+func containerState(s corev1.ContainerState) string {
+	switch {
+	case s.Running != nil:
+		return "Running"
+	case s.Waiting != nil:
+		return s.Waiting.Reason
+	case s.Terminated != nil:
+		return s.Terminated.Reason
+	}
+	return ""
+}
+
+// LogKinds são os kinds cujos logs são os dos pods que eles selecionam.
+var LogKinds = map[string]bool{"deploy": true, "rs": true, "sts": true, "ds": true, "jobs": true, "svc": true}
+
+// PodSelector devolve o seletor de labels dos pods de um workload ou service.
+func PodSelector(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	get := metav1.GetOptions{}
+	var sel *metav1.LabelSelector
+	switch kind {
+	case "deploy":
+		o, err := c.AppsV1().Deployments(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		sel = o.Spec.Selector
+	case "rs":
+		o, err := c.AppsV1().ReplicaSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		sel = o.Spec.Selector
+	case "sts":
+		o, err := c.AppsV1().StatefulSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		sel = o.Spec.Selector
+	case "ds":
+		o, err := c.AppsV1().DaemonSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		sel = o.Spec.Selector
+	case "jobs":
+		o, err := c.BatchV1().Jobs(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		sel = o.Spec.Selector
+	case "svc":
+		o, err := c.CoreV1().Services(namespace).Get(ctx, name, get)
+		if err != nil {
+			return "", err
+		}
+		if len(o.Spec.Selector) == 0 {
+			return "", fmt.Errorf("service %s não tem seletor de pods", name)
+		}
+		return labels.SelectorFromSet(o.Spec.Selector).String(), nil
+	default:
+		return "", fmt.Errorf("%s não tem pods próprios", kind)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return "", err
+	}
+	if selector.Empty() {
+		return "", fmt.Errorf("%s/%s não tem seletor de pods", kind, name)
+	}
+	return selector.String(), nil
+}
+
+// SelectorTargets lista os containers comuns dos pods que casam com selector
+// e que já rodaram ao menos uma vez (os que ainda estão criando entram na
+// próxima consulta). pods é o total de pods selecionados.
+func SelectorTargets(ctx context.Context, c kubernetes.Interface, namespace, selector string) (targets []LogTarget, pods int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	list, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, p := range list.Items {
+		for _, st := range p.Status.ContainerStatuses {
+			if st.State.Waiting != nil && st.RestartCount == 0 {
+				continue
+			}
+			targets = append(targets, LogTarget{Namespace: p.Namespace, Pod: p.Name, Container: st.Name})
+		}
+	}
+	return targets, len(list.Items), nil
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/rest"
+)
+
+// ManifestNode é um nó do objeto vivo visto como árvore, para exibir em
+// YAML ou JSON com seções dobráveis. Mapas e listas têm Children (os de mapa
+// em ordem de chave, como o kubectl); escalares têm só Value.
+type ManifestNode struct {
+	Key      string // chave no mapa pai; vazio em itens de lista e na raiz
+	Value    any    // string, json.Number, bool ou nil
+	Children []*ManifestNode
+	Map      bool
+	List     bool
+	Path     string // ex.: spec.template.spec.containers[0].image
+	Parent   *ManifestNode
+}
+
+// Container diz se o nó é mapa ou lista (pode ser dobrado).
+func (n *ManifestNode) Container() bool {
+	return n.Map || n.List
+}
+
+// FetchManifest lê o objeto vivo pela sessão de cfg e o devolve como árvore.
+func FetchManifest(ctx context.Context, cfg *rest.Config, kind, name, namespace string) (*ManifestNode, error) {
+	obj, err := GetObject(ctx, cfg, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(raw)
+}
+
+// ParseManifest monta a árvore de um objeto JSON; números ficam como vieram.
+func ParseManifest(raw []byte) (*ManifestNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+	return manifestNode(nil, "", "", v), nil
+}
+
+func manifestNode(parent *ManifestNode, key, path string, v any) *ManifestNode {
+	n := &ManifestNode{Key: key, Path: path, Parent: parent}
+	switch val := v.(type) {
+	case map[string]any:
+		n.Map = true
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			n.Children = append(n.Children, manifestNode(n, k, childPath, val[k]))
+		}
+	case []any:
+		n.List = true
+		for i, item := range val {
+			n.Children = append(n.Children, manifestNode(n, "", fmt.Sprintf("%s[%d]", path, i), item))
+		}
+	default:
+		n.Value = v
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// kindResources liga os kinds curtos do painel ao recurso da API.
+var kindResources = map[string]schema.GroupVersionResource{
+	"deploy":          {Group: "apps", Version: "v1", Resource: "deployments"},
+	"rs":              {Group: "apps", Version: "v1", Resource: "replicasets"},
+	"sts":             {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	"ds":              {Group: "apps", Version: "v1", Resource: "daemonsets"},
+	"jobs":            {Group: "batch", Version: "v1", Resource: "jobs"},
+	"cronjobs":        {Group: "batch", Version: "v1", Resource: "cronjobs"},
+	"pods":            {Version: "v1", Resource: "pods"},
+	"svc":             {Version: "v1", Resource: "services"},
+	"ingress":         {Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	"endpoints":       {Version: "v1", Resource: "endpoints"},
+	"pvc":             {Version: "v1", Resource: "persistentvolumeclaims"},
+	"secrets":         {Version: "v1", Resource: "secrets"},
+	"configmaps":      {Version: "v1", Resource: "configmaps"},
+	"serviceaccounts": {Version: "v1", Resource: "serviceaccounts"},
+	"events":          {Version: "v1", Resource: "events"},
+	"nodes":           {Version: "v1", Resource: "nodes"},
+	"pv":              {Version: "v1", Resource: "persistentvolumes"},
+	"ns":              {Version: "v1", Resource: "namespaces"},
+	"crd":             {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+}
+
+// objectResource devolve o client dinâmico do kind; kinds fora da tabela
+// (recursos de CRDs, "cert.cert-manager.io") são resolvidos pelo discovery,
+// com os nomes curtos que o kubectl aceita.
+func objectResource(cfg *rest.Config, kind, namespace string) (dynamic.ResourceInterface, error) {
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	gvr, ok := kindResources[kind]
+	namespaced := !clusterScoped[kind]
+	if !ok {
+		if gvr, namespaced, err = discoverResource(cfg, kind); err != nil {
+			return nil, err
+		}
+	}
+	if !namespaced {
+		return client.Resource(gvr), nil
+	}
+	return client.Resource(gvr).Namespace(namespaceTarget(namespace)), nil
+}
+
+func discoverResource(cfg *rest.Config, kind string) (gvr schema.GroupVersionResource, namespaced bool, err error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return gvr, false, err
+	}
+	mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc)), dc, nil)
+	gvr, err = mapper.ResourceFor(schema.ParseGroupResource(kind).WithVersion(""))
+	if err != nil {
+		return gvr, false, err
+	}
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return gvr, false, err
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return gvr, false, err
+	}
+	return gvr, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// GetObject lê o objeto vivo pelo client dinâmico, como "kubectl get".
+func GetObject(ctx context.Context, cfg *rest.Config, kind, name, namespace string) (*unstructured.Unstructured, error) {
+	res, err := objectResource(cfg, kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	return res.Get(ctx, name, metav1.GetOptions{})
+}
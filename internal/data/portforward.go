@@ -0,0 +1,386 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// ForwardKinds são as linhas de onde sai um port-forward.
+var ForwardKinds = map[string]bool{"pods": true, "svc": true}
+
+// ForwardPort é uma porta declarada no pod ou no service. Port é a do objeto
+// (a do service, ou a do container) e Remote a do pod que recebe o tráfego.
+type ForwardPort struct {
+	Port      int32
+	Remote    int32
+	Name      string
+	Container string
+}
+
+// ForwardTarget resolve o pod que recebe o port-forward e as portas TCP
+// declaradas. Para um service, como o kubectl, usa um pod rodando do
+// selector e traduz cada porta pelo targetPort.
+func ForwardTarget(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) (string, []ForwardPort, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	switch kind {
+	case "pods":
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", nil, err
+		}
+		var ports []ForwardPort
+		for _, ct := range pod.Spec.Containers {
+			for _, p := range ct.Ports {
+				if p.Protocol == "" || p.Protocol == corev1.ProtocolTCP {
+					ports = append(ports, ForwardPort{Port: p.ContainerPort, Remote: p.ContainerPort, Name: p.Name, Container: ct.Name})
+				}
+			}
+		}
+		return pod.Name, ports, nil
+	case "svc":
+		svc, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", nil, err
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return "", nil, fmt.Errorf("svc/%s não tem selector", name)
+		}
+		list, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: svc.Spec.Selector})})
+		if err != nil {
+			return "", nil, err
+		}
+		pod := runningPod(list.Items)
+		if pod == nil {
+			return "", nil, fmt.Errorf("svc/%s não tem pod rodando", name)
+		}
+		var ports []ForwardPort
+		for _, sp := range svc.Spec.Ports {
+			if sp.Protocol != "" && sp.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+			if remote, ct, ok := targetPort(pod, sp); ok {
+				ports = append(ports, ForwardPort{Port: sp.Port, Remote: remote, Name: sp.Name, Container: ct})
+			}
+		}
+		return pod.Name, ports, nil
+	}
+	return "", nil, fmt.Errorf("%s não aceita port-forward", kind)
+}
+
+// runningPod escolhe o pod rodando mais antigo, de preferência pronto.
+func runningPod(pods []corev1.Pod) *corev1.Pod {
+	var best *corev1.Pod
+	ready := func(p *corev1.Pod) bool {
+		for _, cond := range p.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				return cond.Status == corev1.ConditionTrue
+			}
+		}
+		return false
+	}
+	for i := range pods {
+		p := &pods[i]
+		if p.Status.Phase != corev1.PodRunning || p.DeletionTimestamp != nil {
+			continue
+		}
+		switch {
+		case best == nil, ready(p) && !ready(best):
+			best = p
+		case ready(p) == ready(best) && p.CreationTimestamp.Before(&best.CreationTimestamp):
+			best = p
+		}
+	}
+	return best
+}
+
+// targetPort traduz a porta do service para a do container; um targetPort
+// com nome procura a porta de mesmo nome nos containers do pod.
+func targetPort(pod *corev1.Pod, sp corev1.ServicePort) (int32, string, bool) {
+	tp := sp.TargetPort
+	for _, ct := range pod.Spec.Containers {
+		for _, p := range ct.Ports {
+			if (tp.Type == intstr.String && p.Name == tp.StrVal) || (tp.Type == intstr.Int && p.ContainerPort == tp.IntVal) {
+				return p.ContainerPort, ct.Name, true
+			}
+		}
+	}
+	switch {
+	case tp.Type == intstr.String:
+		return 0, "", false
+	case tp.IntVal == 0:
+		return sp.Port, "", true
+	}
+	return tp.IntVal, "", true
+}
+
+// PortForward é um port-forward em segundo plano de 127.0.0.1:Local para a
+// porta Remote do pod. Sobrevive à navegação; Stop encerra.
+type PortForward struct {
+	ID        int // atribuído pela UI; identifica a linha no painel
+	Context   string
+	Namespace string
+	Pod       string
+	Via       string // svc/nome quando aberto a partir de um service
+	Local     int
+	Remote    int
+	Started   time.Time
+
+	in, out atomic.Int64
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu        sync.Mutex
+	err       string // motivo do fim
+	lastError string // erro do último encaminhamento (ex.: connection refused no pod)
+	stopping  bool
+}
+
+// StartPortForward abre o port-forward e espera o listener local; local 0
+// escolhe uma porta livre.
+func StartPortForward(cfg *rest.Config, c kubernetes.Interface, kubeContext, namespace, pod string, local, remote int) (*PortForward, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	url := c.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward").URL()
+	f := &PortForward{
+		Context:   kubeContext,
+		Namespace: namespace,
+		Pod:       pod,
+		Local:     local,
+		Remote:    remote,
+		Started:   time.Now(),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	dialer := &countingDialer{Dialer: spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), f: f}
+	ready := make(chan struct{})
+	var listenErr bytes.Buffer // "Unable to listen on port ..." quando a porta local está ocupada
+	spec := fmt.Sprintf("%d:%d", local, remote)
+	pf, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{spec}, f.stop, ready, io.Discard, &listenErr)
+	if err != nil {
+		return nil, err
+	}
+	failed := make(chan error, 1)
+	go func() {
+		err := pf.ForwardPorts()
+		f.mu.Lock()
+		switch {
+		case f.stopping:
+		case errors.Is(err, portforward.ErrLostConnectionToPod):
+			f.err = "conexão com o pod perdida"
+		case err != nil:
+			f.err = err.Error()
+		}
+		f.mu.Unlock()
+		close(f.done)
+		failed <- err
+	}()
+	select {
+	case <-ready:
+	case err := <-failed:
+		if msg := strings.TrimSpace(listenErr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		if err == nil {
+			err = errors.New("port-forward encerrado antes de abrir a porta")
+		}
+		return nil, err
+	}
+	if ports, err := pf.GetPorts(); err == nil && len(ports) > 0 {
+		f.Local = int(ports[0].Local)
+	}
+	return f, nil
+}
+
+// Stop encerra o port-forward e espera o listener fechar.
+func (f *PortForward) Stop() {
+	f.mu.Lock()
+	if !f.stopping {
+		f.stopping = true
+		close(f.stop)
+	}
+	f.mu.Unlock()
+	<-f.done
+}
+
+// Running diz se o port-forward ainda está de pé.
+func (f *PortForward) Running() bool {
+	select {
+	case <-f.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Bytes são os totais recebidos do pod (in) e enviados a ele (out).
+func (f *PortForward) Bytes() (in, out int64) {
+	return f.in.Load(), f.out.Load()
+}
+
+// Status descreve o estado: rodando, encerrado ou o erro que o derrubou.
+func (f *PortForward) Status() (string, Health) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case f.Running() && f.lastError != "":
+		return "Running (" + f.lastError + ")", HealthWarning
+	case f.Running():
+		return "Running", HealthOK
+	case f.err != "" && f.lastError != "":
+		return f.err + " (" + f.lastError + ")", HealthError
+	case f.err != "":
+		return f.err, HealthError
+	}
+	return "Stopped", HealthUnknown
+}
+
+// Target é pod:porta (ou via service) para mensagens.
+func (f *PortForward) Target() string {
+	target := fmt.Sprintf("pods/%s:%d", f.Pod, f.Remote)
+	if f.Via != "" {
+		target = f.Via + " → " + target
+	}
+	return target
+}
+
+// ForwardTable monta o painel PORT-FORWARDS; UID é o ID do port-forward,
+// que não muda quando outro sai da lista.
+func ForwardTable(forwards []*PortForward) Table {
+	t := Table{Kind: "portforward", Header: []string{"LOCAL", "NAMESPACE", "TARGET", "CONTEXT", "IN", "OUT", "AGE", "STATUS"}}
+	for _, f := range forwards {
+		in, out := f.Bytes()
+		status, health := f.Status()
+		local := "127.0.0.1:" + strconv.Itoa(f.Local)
+		t.Rows = append(t.Rows, Row{
+			Kind:      "portforward",
+			Name:      local,
+			UID:       strconv.Itoa(f.ID),
+			Namespace: f.Namespace,
+			Created:   f.Started,
+			Status:    status,
+			Health:    health,
+			Columns:   []string{local, f.Namespace, f.Target(), f.Context, byteSize(in), byteSize(out), age(metav1.NewTime(f.Started)), status},
+		})
+	}
+	return t
+}
+
+func byteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+	value, suffix := float64(n)/unit, "Ki"
+	for _, s := range []string{"Mi", "Gi", "Ti"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, s
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
+
+// countingDialer embrulha a conexão do port-forward para contar os bytes dos
+// streams de dados e guardar as mensagens dos streams de erro.
+type countingDialer struct {
+	httpstream.Dialer
+	f *PortForward
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &countingConn{Connection: conn, f: d.f}, protocol, nil
+}
+
+type countingConn struct {
+	httpstream.Connection
+	f *PortForward
+}
+
+func (c *countingConn) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	s, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	if headers.Get(corev1.StreamType) == corev1.StreamTypeError {
+		// nova conexão local: o erro da anterior deixa de valer
+		c.f.mu.Lock()
+		c.f.lastError = ""
+		c.f.mu.Unlock()
+	}
+	return &countingStream{Stream: s, f: c.f, kind: headers.Get(corev1.StreamType)}, nil
+}
+
+// RemoveStreams recebe os streams embrulhados; a conexão conhece os originais.
+func (c *countingConn) RemoveStreams(streams ...httpstream.Stream) {
+	inner := make([]httpstream.Stream, 0, len(streams))
+	for _, s := range streams {
+		if cs, ok := s.(*countingStream); ok {
+			s = cs.Stream
+		}
+		inner = append(inner, s)
+	}
+	c.Connection.RemoveStreams(inner...)
+}
+
+type countingStream struct {
+	httpstream.Stream
+	f    *PortForward
+	kind string
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	switch s.kind {
+	case corev1.StreamTypeData:
+		s.f.in.Add(int64(n))
+	case corev1.StreamTypeError:
+		if msg := strings.TrimSpace(string(p[:n])); msg != "" {
+			s.f.mu.Lock()
+			s.f.lastError = lastLine(msg)
+			s.f.mu.Unlock()
+		}
+	}
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	if s.kind == corev1.StreamTypeData {
+		s.f.out.Add(int64(n))
+	}
+	return n, err
+}
+
+// lastLine fica com a parte final e legível da mensagem do kubelet.
+func lastLine(msg string) string {
+	if i := strings.LastIndex(msg, ": "); i >= 0 && i+2 < len(msg) {
+		msg = msg[i+2:]
+	}
+	return msg
+}
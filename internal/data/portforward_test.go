@@ -0,0 +1,113 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestTargetPort(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+		{Name: "metrics", Ports: []corev1.ContainerPort{{Name: "prom", ContainerPort: 9090}}},
+	}}}
+	tests := []struct {
+		name          string
+		sp            corev1.ServicePort
+		wantPort      int32
+		wantContainer string
+		wantOK        bool
+	}{
+		{"named", corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")}, 8080, "app", true},
+		{"named in second container", corev1.ServicePort{Port: 9000, TargetPort: intstr.FromString("prom")}, 9090, "metrics", true},
+		{"unknown name", corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("grpc")}, 0, "", false},
+		{"declared number", corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt32(8080)}, 8080, "app", true},
+		{"undeclared number", corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt32(3000)}, 3000, "", true},
+		{"no targetPort uses port", corev1.ServicePort{Port: 8080}, 8080, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, container, ok := targetPort(pod, tt.sp)
+			if port != tt.wantPort || container != tt.wantContainer || ok != tt.wantOK {
+				t.Errorf("targetPort() = (%d, %q, %v), want (%d, %q, %v)", port, container, ok, tt.wantPort, tt.wantContainer, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRunningPod(t *testing.T) {
+	base := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	pod := func(name string, phase corev1.PodPhase, ready bool, ageMin int) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(base.Add(-time.Duration(ageMin) * time.Minute))},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	terminating := pod("terminating", corev1.PodRunning, true, 90)
+	terminating.DeletionTimestamp = &metav1.Time{Time: base}
+
+	tests := []struct {
+		name string
+		pods []corev1.Pod
+		want string
+	}{
+		{"none", nil, ""},
+		{"only pending", []corev1.Pod{pod("p", corev1.PodPending, false, 1)}, ""},
+		{"ready beats older", []corev1.Pod{pod("old", corev1.PodRunning, false, 60), pod("ready", corev1.PodRunning, true, 5)}, "ready"},
+		{"oldest among ready", []corev1.Pod{pod("new", corev1.PodRunning, true, 5), pod("old", corev1.PodRunning, true, 60)}, "old"},
+		{"not ready if nothing else", []corev1.Pod{pod("failed", corev1.PodFailed, false, 60), pod("starting", corev1.PodRunning, false, 1)}, "starting"},
+		{"skips terminating", []corev1.Pod{terminating, pod("next", corev1.PodRunning, true, 5)}, "next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if p := runningPod(tt.pods); p != nil {
+				got = p.Name
+			}
+			if got != tt.want {
+				t.Errorf("runningPod() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0Ki"},
+		{1536, "1.5Ki"},
+		{5 << 20, "5.0Mi"},
+		{3 << 30, "3.0Gi"},
+		{2 << 40, "2.0Ti"},
+		{2048 << 40, "2048.0Ti"},
+	}
+	for _, tt := range tests {
+		if got := byteSize(tt.n); got != tt.want {
+			t.Errorf("byteSize(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestForwardTableUsesID(t *testing.T) {
+	forwards := []*PortForward{{ID: 3, Local: 8080}, {ID: 7, Local: 9090}}
+	table := ForwardTable(forwards)
+	for i, want := range []string{"3", "7"} {
+		if got := table.Rows[i].UID; got != want {
+			t.Errorf("row %d UID = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,551 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// printer reproduz as colunas de "kubectl get" para um kind.
+type printer struct {
+	header []string
+	row    func(obj runtime.Object) []string
+}
+
+var printers = map[string]printer{
+	"pods":            {[]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"}, podRow},
+	"deploy":          {[]string{"NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"}, deploymentRow},
+	"rs":              {[]string{"NAME", "DESIRED", "CURRENT", "READY", "AGE"}, replicaSetRow},
+	"sts":             {[]string{"NAME", "READY", "AGE"}, statefulSetRow},
+	"ds":              {[]string{"NAME", "DESIRED", "CURRENT", "READY", "UP-TO-DATE", "AVAILABLE", "NODE SELECTOR", "AGE"}, daemonSetRow},
+	"jobs":            {[]string{"NAME", "COMPLETIONS", "DURATION", "AGE"}, jobRow},
+	"cronjobs":        {[]string{"NAME", "SCHEDULE", "TIMEZONE", "SUSPEND", "ACTIVE", "LAST SCHEDULE", "AGE"}, cronJobRow},
+	"svc":             {[]string{"NAME", "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE"}, serviceRow},
+	"ingress":         {[]string{"NAME", "CLASS", "HOSTS", "ADDRESS", "PORTS", "AGE"}, ingressRow},
+	"endpoints":       {[]string{"NAME", "ENDPOINTS", "AGE"}, endpointsRow},
+	"pvc":             {[]string{"NAME", "STATUS", "VOLUME", "CAPACITY", "ACCESS MODES", "STORAGECLASS", "AGE"}, pvcRow},
+	"pv":              {[]string{"NAME", "CAPACITY", "ACCESS MODES", "RECLAIM POLICY", "STATUS", "CLAIM", "STORAGECLASS", "REASON", "AGE"}, pvRow},
+	"secrets":         {[]string{"NAME", "TYPE", "DATA", "AGE"}, secretRow},
+	"configmaps":      {[]string{"NAME", "DATA", "AGE"}, configMapRow},
+	"serviceaccounts": {[]string{"NAME", "SECRETS", "AGE"}, serviceAccountRow},
+	"nodes":           {[]string{"NAME", "STATUS", "ROLES", "AGE", "VERSION"}, nodeRow},
+	"crd":             {[]string{"NAME", "CREATED AT"}, crdRow},
+	"events":          {[]string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}, eventRow},
+	"ns":              {[]string{"NAME", "STATUS", "AGE"}, namespaceRow},
+}
+
+// newTable converte objetos em linhas tipadas; withNS prefixa NAMESPACE (-A).
+func newTable(kind string, objs []runtime.Object, withNS bool) Table {
+	p, ok := printers[kind]
+	if !ok {
+		return Table{Kind: kind}
+	}
+	t := Table{Kind: kind, Header: p.header}
+	if withNS {
+		t.Header = append([]string{"NAMESPACE"}, p.header...)
+	}
+	t.Rows = make([]Row, 0, len(objs))
+	for _, obj := range objs {
+		row := Row{Kind: kind, Columns: p.row(obj)}
+		if m, ok := obj.(metav1.Object); ok {
+			row.Namespace = m.GetNamespace()
+			row.Name = m.GetName()
+			row.UID = string(m.GetUID())
+			row.Created = m.GetCreationTimestamp().Time
+			row.Labels = m.GetLabels()
+		}
+		row.Status, row.Health = statusOf(obj)
+		if withNS {
+			row.Columns = append([]string{row.Namespace}, row.Columns...)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t.Time))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func int32Value(p *int32) int32 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func podRow(obj runtime.Object) []string {
+	pod := obj.(*corev1.Pod)
+	ready := 0
+	restarts := 0
+	var lastRestart time.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		restarts += int(cs.RestartCount)
+		if t := cs.LastTerminationState.Terminated; t != nil && t.FinishedAt.After(lastRestart) {
+			lastRestart = t.FinishedAt.Time
+		}
+		if cs.Ready && cs.State.Running != nil {
+			ready++
+		}
+	}
+	restartsCell := strconv.Itoa(restarts)
+	if restarts > 0 && !lastRestart.IsZero() {
+		restartsCell = fmt.Sprintf("%d (%s ago)", restarts, duration.HumanDuration(time.Since(lastRestart)))
+	}
+	return []string{
+		pod.Name,
+		fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)),
+		podStatus(pod),
+		restartsCell,
+		age(pod.CreationTimestamp),
+	}
+}
+
+// podStatus segue a mesma precedência da coluna STATUS do kubectl.
+func podStatus(pod *corev1.Pod) string {
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+
+	initializing := false
+	for i, cs := range pod.Status.InitContainerStatuses {
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			continue
+		case cs.State.Terminated != nil:
+			switch {
+			case cs.State.Terminated.Reason != "":
+				reason = "Init:" + cs.State.Terminated.Reason
+			case cs.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Init:Signal:%d", cs.State.Terminated.Signal)
+			default:
+				reason = fmt.Sprintf("Init:ExitCode:%d", cs.State.Terminated.ExitCode)
+			}
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + cs.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing {
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			cs := pod.Status.ContainerStatuses[i]
+			switch {
+			case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+				reason = cs.State.Waiting.Reason
+			case cs.State.Terminated != nil && cs.State.Terminated.Reason != "":
+				reason = cs.State.Terminated.Reason
+			case cs.State.Terminated != nil && cs.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Signal:%d", cs.State.Terminated.Signal)
+			case cs.State.Terminated != nil:
+				reason = fmt.Sprintf("ExitCode:%d", cs.State.Terminated.ExitCode)
+			case cs.Ready && cs.State.Running != nil:
+				hasRunning = true
+			}
+		}
+		if reason == "Completed" && hasRunning {
+			reason = "NotReady"
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+					reason = "Running"
+				}
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.Reason == "NodeLost" {
+			return "Unknown"
+		}
+		return "Terminating"
+	}
+	return reason
+}
+
+func deploymentRow(obj runtime.Object) []string {
+	d := obj.(*appsv1.Deployment)
+	return []string{
+		d.Name,
+		fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, int32Value(d.Spec.Replicas)),
+		strconv.Itoa(int(d.Status.UpdatedReplicas)),
+		strconv.Itoa(int(d.Status.AvailableReplicas)),
+		age(d.CreationTimestamp),
+	}
+}
+
+func replicaSetRow(obj runtime.Object) []string {
+	rs := obj.(*appsv1.ReplicaSet)
+	return []string{
+		rs.Name,
+		strconv.Itoa(int(int32Value(rs.Spec.Replicas))),
+		strconv.Itoa(int(rs.Status.Replicas)),
+		strconv.Itoa(int(rs.Status.ReadyReplicas)),
+		age(rs.CreationTimestamp),
+	}
+}
+
+func statefulSetRow(obj runtime.Object) []string {
+	sts := obj.(*appsv1.StatefulSet)
+	return []string{
+		sts.Name,
+		fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, int32Value(sts.Spec.Replicas)),
+		age(sts.CreationTimestamp),
+	}
+}
+
+func daemonSetRow(obj runtime.Object) []string {
+	ds := obj.(*appsv1.DaemonSet)
+	return []string{
+		ds.Name,
+		strconv.Itoa(int(ds.Status.DesiredNumberScheduled)),
+		strconv.Itoa(int(ds.Status.CurrentNumberScheduled)),
+		strconv.Itoa(int(ds.Status.NumberReady)),
+		strconv.Itoa(int(ds.Status.UpdatedNumberScheduled)),
+		strconv.Itoa(int(ds.Status.NumberAvailable)),
+		labels.FormatLabels(ds.Spec.Template.Spec.NodeSelector),
+		age(ds.CreationTimestamp),
+	}
+}
+
+func jobRow(obj runtime.Object) []string {
+	job := obj.(*batchv1.Job)
+	var completions string
+	switch parallelism := int32Value(job.Spec.Parallelism); {
+	case job.Spec.Completions != nil:
+		completions = fmt.Sprintf("%d/%d", job.Status.Succeeded, *job.Spec.Completions)
+	case parallelism > 1:
+		completions = fmt.Sprintf("%d/1 of %d", job.Status.Succeeded, parallelism)
+	default:
+		completions = fmt.Sprintf("%d/1", job.Status.Succeeded)
+	}
+	var jobDuration string
+	switch {
+	case job.Status.StartTime == nil:
+	case job.Status.CompletionTime == nil:
+		jobDuration = duration.HumanDuration(time.Since(job.Status.StartTime.Time))
+	default:
+		jobDuration = duration.HumanDuration(job.Status.CompletionTime.Sub(job.Status.StartTime.Time))
+	}
+	return []string{job.Name, completions, jobDuration, age(job.CreationTimestamp)}
+}
+
+func cronJobRow(obj runtime.Object) []string {
+	cj := obj.(*batchv1.CronJob)
+	tz := "<none>"
+	if cj.Spec.TimeZone != nil {
+		tz = *cj.Spec.TimeZone
+	}
+	suspend := "False"
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		suspend = "True"
+	}
+	last := "<none>"
+	if cj.Status.LastScheduleTime != nil {
+		last = age(*cj.Status.LastScheduleTime)
+	}
+	return []string{
+		cj.Name,
+		cj.Spec.Schedule,
+		tz,
+		suspend,
+		strconv.Itoa(len(cj.Status.Active)),
+		last,
+		age(cj.CreationTimestamp),
+	}
+}
+
+func serviceRow(obj runtime.Object) []string {
+	svc := obj.(*corev1.Service)
+	var external []string
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeExternalName:
+		external = append(external, svc.Spec.ExternalName)
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ing := range svc.Status.LoadBalancer.Ingress {
+			if ing.IP != "" {
+				external = append(external, ing.IP)
+			} else if ing.Hostname != "" {
+				external = append(external, ing.Hostname)
+			}
+		}
+		external = append(external, svc.Spec.ExternalIPs...)
+		if len(external) == 0 {
+			external = append(external, "<pending>")
+		}
+	default:
+		external = append(external, svc.Spec.ExternalIPs...)
+	}
+
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		if p.NodePort != 0 {
+			ports = append(ports, fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
+		}
+	}
+	return []string{
+		svc.Name,
+		string(svc.Spec.Type),
+		orNone(svc.Spec.ClusterIP),
+		orNone(strings.Join(external, ",")),
+		orNone(strings.Join(ports, ",")),
+		age(svc.CreationTimestamp),
+	}
+}
+
+func ingressRow(obj runtime.Object) []string {
+	ing := obj.(*networkingv1.Ingress)
+	class := "<none>"
+	if ing.Spec.IngressClassName != nil {
+		class = *ing.Spec.IngressClassName
+	}
+	var hosts []string
+	for _, r := range ing.Spec.Rules {
+		if r.Host != "" {
+			hosts = append(hosts, r.Host)
+		}
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+	var addrs []string
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addrs = append(addrs, lb.IP)
+		} else if lb.Hostname != "" {
+			addrs = append(addrs, lb.Hostname)
+		}
+	}
+	ports := "80"
+	if len(ing.Spec.TLS) > 0 {
+		ports = "80, 443"
+	}
+	return []string{ing.Name, class, strings.Join(hosts, ","), strings.Join(addrs, ","), ports, age(ing.CreationTimestamp)}
+}
+
+func endpointsRow(obj runtime.Object) []string {
+	ep := obj.(*corev1.Endpoints)
+	const maxShown = 3
+	var list []string
+	total := 0
+	for _, ss := range ep.Subsets {
+		for _, addr := range ss.Addresses {
+			if len(ss.Ports) == 0 {
+				total++
+				if len(list) < maxShown {
+					list = append(list, addr.IP)
+				}
+				continue
+			}
+			for _, p := range ss.Ports {
+				total++
+				if len(list) < maxShown {
+					list = append(list, fmt.Sprintf("%s:%d", addr.IP, p.Port))
+				}
+			}
+		}
+	}
+	cell := orNone(strings.Join(list, ","))
+	if total > maxShown {
+		cell += fmt.Sprintf(" + %d more...", total-maxShown)
+	}
+	return []string{ep.Name, cell, age(ep.CreationTimestamp)}
+}
+
+func accessModes(modes []corev1.PersistentVolumeAccessMode) string {
+	var out []string
+	for _, m := range modes {
+		switch m {
+		case corev1.ReadWriteOnce:
+			out = append(out, "RWO")
+		case corev1.ReadOnlyMany:
+			out = append(out, "ROX")
+		case corev1.ReadWriteMany:
+			out = append(out, "RWX")
+		case corev1.ReadWriteOncePod:
+			out = append(out, "RWOP")
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+func storageQuantity(list corev1.ResourceList) string {
+	if q, ok := list[corev1.ResourceStorage]; ok {
+		return q.String()
+	}
+	return ""
+}
+
+func pvcRow(obj runtime.Object) []string {
+	pvc := obj.(*corev1.PersistentVolumeClaim)
+	capacity, modes := "", ""
+	if pvc.Spec.VolumeName != "" {
+		capacity = storageQuantity(pvc.Status.Capacity)
+		modes = accessModes(pvc.Status.AccessModes)
+	}
+	class := ""
+	if pvc.Spec.StorageClassName != nil {
+		class = *pvc.Spec.StorageClassName
+	}
+	status := string(pvc.Status.Phase)
+	if pvc.DeletionTimestamp != nil {
+		status = "Terminating"
+	}
+	return []string{pvc.Name, status, pvc.Spec.VolumeName, capacity, modes, class, age(pvc.CreationTimestamp)}
+}
+
+func pvRow(obj runtime.Object) []string {
+	pv := obj.(*corev1.PersistentVolume)
+	claim := ""
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		claim = ref.Namespace + "/" + ref.Name
+	}
+	status := string(pv.Status.Phase)
+	if pv.DeletionTimestamp != nil {
+		status = "Terminating"
+	}
+	return []string{
+		pv.Name,
+		storageQuantity(pv.Spec.Capacity),
+		accessModes(pv.Spec.AccessModes),
+		string(pv.Spec.PersistentVolumeReclaimPolicy),
+		status,
+		claim,
+		pv.Spec.StorageClassName,
+		pv.Status.Reason,
+		age(pv.CreationTimestamp),
+	}
+}
+
+func secretRow(obj runtime.Object) []string {
+	s := obj.(*corev1.Secret)
+	return []string{s.Name, string(s.Type), strconv.Itoa(len(s.Data)), age(s.CreationTimestamp)}
+}
+
+func configMapRow(obj runtime.Object) []string {
+	cm := obj.(*corev1.ConfigMap)
+	return []string{cm.Name, strconv.Itoa(len(cm.Data) + len(cm.BinaryData)), age(cm.CreationTimestamp)}
+}
+
+func serviceAccountRow(obj runtime.Object) []string {
+	sa := obj.(*corev1.ServiceAccount)
+	return []string{sa.Name, strconv.Itoa(len(sa.Secrets)), age(sa.CreationTimestamp)}
+}
+
+func nodeRow(obj runtime.Object) []string {
+	n := obj.(*corev1.Node)
+	status := "Unknown"
+	for _, cond := range n.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+		switch cond.Status {
+		case corev1.ConditionTrue:
+			status = "Ready"
+		case corev1.ConditionFalse:
+			status = "NotReady"
+		}
+	}
+	if n.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	var roles []string
+	for k, v := range n.Labels {
+		switch {
+		case strings.HasPrefix(k, "node-role.kubernetes.io/"):
+			if role := strings.TrimPrefix(k, "node-role.kubernetes.io/"); role != "" {
+				roles = append(roles, role)
+			}
+		case k == "kubernetes.io/role" && v != "":
+			roles = append(roles, v)
+		}
+	}
+	sort.Strings(roles)
+	return []string{n.Name, status, orNone(strings.Join(roles, ",")), age(n.CreationTimestamp), n.Status.NodeInfo.KubeletVersion}
+}
+
+func crdRow(obj runtime.Object) []string {
+	m := obj.(*metav1.PartialObjectMetadata)
+	return []string{m.Name, m.CreationTimestamp.UTC().Format(time.RFC3339)}
+}
+
+func eventTime(ev *corev1.Event) metav1.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp
+	case !ev.EventTime.IsZero():
+		return metav1.NewTime(ev.EventTime.Time)
+	default:
+		return ev.CreationTimestamp
+	}
+}
+
+func eventRow(obj runtime.Object) []string {
+	ev := obj.(*corev1.Event)
+	object := strings.ToLower(ev.InvolvedObject.Kind) + "/" + ev.InvolvedObject.Name
+	return []string{age(eventTime(ev)), ev.Type, ev.Reason, object, strings.TrimSpace(ev.Message)}
+}
+
+func namespaceRow(obj runtime.Object) []string {
+	ns := obj.(*corev1.Namespace)
+	return []string{ns.Name, string(ns.Status.Phase), age(ns.CreationTimestamp)}
+}
+
+// newMetricsTable reproduz "kubectl top pods".
+func newMetricsTable(items []PodMetrics, withNS bool) Table {
+	t := Table{Kind: "pods", Header: []string{"NAME", "CPU(cores)", "MEMORY(bytes)"}}
+	if withNS {
+		t.Header = append([]string{"NAMESPACE"}, t.Header...)
+	}
+	for _, pm := range items {
+		var cpu, mem resource.Quantity
+		for _, c := range pm.Containers {
+			cpu.Add(c.Usage[corev1.ResourceCPU])
+			mem.Add(c.Usage[corev1.ResourceMemory])
+		}
+		row := Row{
+			Kind:      "pods",
+			Namespace: pm.Namespace,
+			Name:      pm.Name,
+			UID:       string(pm.UID),
+			Columns: []string{
+				pm.Name,
+				fmt.Sprintf("%dm", cpu.MilliValue()),
+				fmt.Sprintf("%dMi", mem.Value()/(1024*1024)),
+			},
+		}
+		if withNS {
+			row.Columns = append([]string{pm.Namespace}, row.Columns...)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
@@ -0,0 +1,238 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HistoryKinds são os workloads com histórico de rollout.
+var HistoryKinds = map[string]bool{"deploy": true, "sts": true, "ds": true}
+
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Revision é uma revisão do rollout: um ReplicaSet do deployment ou uma
+// ControllerRevision do statefulset/daemonset.
+type Revision struct {
+	Number      int64
+	Name        string // ReplicaSet ou ControllerRevision
+	Created     time.Time
+	ChangeCause string
+	Images      []string
+	Template    corev1.PodTemplateSpec
+	Current     bool
+
+	patch []byte // o que o rollback aplica (ControllerRevision)
+}
+
+// RolloutHistory lista as revisões do workload da mais nova para a mais
+// antiga, como "kubectl rollout history".
+func RolloutHistory(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) ([]Revision, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	var revs []Revision
+	var err error
+	switch kind {
+	case "deploy":
+		revs, err = deploymentRevisions(ctx, c, namespace, name)
+	case "sts", "ds":
+		revs, err = controllerRevisions(ctx, c, kind, namespace, name)
+	default:
+		return nil, fmt.Errorf("%s não tem histórico de rollout", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i].Number > revs[j].Number })
+	return revs, nil
+}
+
+func deploymentRevisions(ctx context.Context, c kubernetes.Interface, namespace, name string) ([]Revision, error) {
+	d, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	list, err := c.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var revs []Revision
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOf(&rs); ref == nil || ref.UID != d.UID {
+			continue
+		}
+		n, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		tmpl := *rs.Spec.Template.DeepCopy()
+		delete(tmpl.Labels, appsv1.DefaultDeploymentUniqueLabelKey) // muda a cada revisão
+		revs = append(revs, Revision{
+			Number:      n,
+			Name:        rs.Name,
+			Created:     rs.CreationTimestamp.Time,
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Images:      templateImages(tmpl),
+			Template:    tmpl,
+			Current:     rs.Annotations[revisionAnnotation] == d.Annotations[revisionAnnotation],
+		})
+	}
+	return revs, nil
+}
+
+func controllerRevisions(ctx context.Context, c kubernetes.Interface, kind, namespace, name string) ([]Revision, error) {
+	var (
+		uid     types.UID
+		sel     *metav1.LabelSelector
+		current string // nome da revisão em uso; vazio = a mais nova (daemonset)
+	)
+	apps := c.AppsV1()
+	if kind == "sts" {
+		s, err := apps.StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		uid, sel, current = s.UID, s.Spec.Selector, s.Status.UpdateRevision
+	} else {
+		ds, err := apps.DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		uid, sel = ds.UID, ds.Spec.Selector
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return nil, err
+	}
+	list, err := apps.ControllerRevisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var revs []Revision
+	var newest int64
+	for _, cr := range list.Items {
+		if ref := metav1.GetControllerOf(&cr); ref == nil || ref.UID != uid {
+			continue
+		}
+		// Data guarda o patch {"spec":{"template":...}} que o rollback reaplica
+		var patch struct {
+			Spec struct {
+				Template corev1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal(cr.Data.Raw, &patch); err != nil {
+			return nil, fmt.Errorf("revisão %s: %w", cr.Name, err)
+		}
+		tmpl := patch.Spec.Template
+		revs = append(revs, Revision{
+			Number:      cr.Revision,
+			Name:        cr.Name,
+			Created:     cr.CreationTimestamp.Time,
+			ChangeCause: cr.Annotations[changeCauseAnnotation],
+			Images:      templateImages(tmpl),
+			Template:    tmpl,
+			Current:     current != "" && cr.Name == current,
+			patch:       cr.Data.Raw,
+		})
+		newest = max(newest, cr.Revision)
+	}
+	if current == "" {
+		for i := range revs {
+			revs[i].Current = revs[i].Number == newest
+		}
+	}
+	return revs, nil
+}
+
+// TemplateManifest é o pod template da revisão como árvore, para o diff.
+func (r Revision) TemplateManifest() (*ManifestNode, error) {
+	raw, err := json.Marshal(r.Template)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(raw)
+}
+
+// HistoryTable monta a tabela das revisões, com * na atual; base (a revisão
+// marcada para comparar) fica amarela.
+func HistoryTable(revs []Revision, base int64) Table {
+	t := Table{Kind: "revision", Header: []string{"REVISION", "CURRENT", "AGE", "IMAGES", "CHANGE-CAUSE"}}
+	for _, r := range revs {
+		current, health := "", HealthUnknown
+		if r.Current {
+			current, health = "*", HealthOK
+		}
+		if r.Number == base {
+			health = HealthWarning
+		}
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		t.Rows = append(t.Rows, Row{
+			Kind:    "revision",
+			Name:    strconv.FormatInt(r.Number, 10),
+			UID:     r.Name,
+			Created: r.Created,
+			Columns: []string{strconv.FormatInt(r.Number, 10), current, age(metav1.NewTime(r.Created)), strings.Join(r.Images, ","), cause},
+			Health:  health,
+		})
+	}
+	return t
+}
+
+func templateImages(t corev1.PodTemplateSpec) []string {
+	var images []string
+	for _, ct := range t.Spec.Containers {
+		images = append(images, ct.Image)
+	}
+	return images
+}
+
+// Rollback volta o workload ao template da revisão, como
+// "kubectl rollout undo --to-revision".
+func Rollback(ctx context.Context, c kubernetes.Interface, kind, namespace, name string, rev Revision) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	opts := metav1.PatchOptions{}
+	apps := c.AppsV1()
+	switch kind {
+	case "deploy":
+		// como o kubectl: num deployment pausado o template mudaria sem rollout
+		deploy, err := apps.Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deploy.Spec.Paused {
+			return fmt.Errorf("deploy/%s está pausado; retome o rollout antes (x → resume) e tente de novo", name)
+		}
+		tmpl, err := json.Marshal(rev.Template)
+		if err != nil {
+			return err
+		}
+		patch := fmt.Sprintf(`[{"op":"replace","path":"/spec/template","value":%s}]`, tmpl)
+		_, err = apps.Deployments(namespace).Patch(ctx, name, types.JSONPatchType, []byte(patch), opts)
+		return err
+	case "sts":
+		_, err := apps.StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, rev.patch, opts)
+		return err
+	case "ds":
+		_, err := apps.DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, rev.patch, opts)
+		return err
+	}
+	return fmt.Errorf("%s não tem rollback", kind)
+}
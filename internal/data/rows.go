@@ -0,0 +1,146 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Table é a listagem de um kind, com o cabeçalho que o kubectl imprimiria.
+type Table struct {
+	Kind   string
+	Header []string
+	Rows   []Row
+	Err    error
+}
+
+// Row é uma linha da listagem com a identidade real do objeto.
+// Columns segue a ordem de Table.Header; a renderização fica com a UI.
+type Row struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       string
+	Created   time.Time
+	Labels    map[string]string
+	Columns   []string
+	Status    string
+	Health    Health
+}
+
+// Health classifica o Status para a UI colorir a linha.
+type Health int
+
+const (
+	HealthUnknown Health = iota
+	HealthOK
+	HealthWarning
+	HealthError
+)
+
+// Summary são as contagens do OVERVIEW, por kind.
+type Summary struct {
+	Namespace  string
+	Namespaces []string // conjunto marcado em NAMESPACES; substitui Namespace
+	Selector   string   // seletores ativos, como flags do kubectl
+	Counts     map[string]int
+}
+
+var podErrorReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"Error":                      true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerError":       true,
+	"CreateContainerConfigError": true,
+	"InvalidImageName":           true,
+	"OOMKilled":                  true,
+	"Failed":                     true,
+	"Unknown":                    true,
+	"Evicted":                    true,
+}
+
+func podHealth(status string) Health {
+	switch {
+	case status == "Running" || status == "Completed" || status == "Succeeded":
+		return HealthOK
+	case podErrorReasons[status],
+		strings.HasPrefix(status, "ExitCode:"),
+		strings.HasPrefix(status, "Signal:"),
+		strings.HasPrefix(status, "Init:") && podErrorReasons[strings.TrimPrefix(status, "Init:")]:
+		return HealthError
+	default:
+		return HealthWarning
+	}
+}
+
+func replicasHealth(ready, desired int32) Health {
+	if ready >= desired {
+		return HealthOK
+	}
+	return HealthWarning
+}
+
+// statusOf resume o estado do objeto (texto do kubectl + Health).
+func statusOf(obj runtime.Object) (string, Health) {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		status := podStatus(o)
+		return status, podHealth(status)
+	case *appsv1.Deployment:
+		return "", replicasHealth(o.Status.AvailableReplicas, int32Value(o.Spec.Replicas))
+	case *appsv1.StatefulSet:
+		return "", replicasHealth(o.Status.ReadyReplicas, int32Value(o.Spec.Replicas))
+	case *appsv1.ReplicaSet:
+		return "", replicasHealth(o.Status.ReadyReplicas, int32Value(o.Spec.Replicas))
+	case *appsv1.DaemonSet:
+		return "", replicasHealth(o.Status.NumberReady, o.Status.DesiredNumberScheduled)
+	case *batchv1.Job:
+		for _, cond := range o.Status.Conditions {
+			if cond.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch cond.Type {
+			case batchv1.JobFailed:
+				return "Failed", HealthError
+			case batchv1.JobComplete:
+				return "Complete", HealthOK
+			}
+		}
+		return "Running", HealthOK
+	case *corev1.Node:
+		status := nodeRow(o)[1]
+		if strings.HasPrefix(status, "Ready") {
+			return status, HealthOK
+		}
+		return status, HealthError
+	case *corev1.PersistentVolumeClaim:
+		switch o.Status.Phase {
+		case corev1.ClaimBound:
+			return string(o.Status.Phase), HealthOK
+		case corev1.ClaimLost:
+			return string(o.Status.Phase), HealthError
+		}
+		return string(o.Status.Phase), HealthWarning
+	case *corev1.PersistentVolume:
+		if o.Status.Phase == corev1.VolumeFailed {
+			return string(o.Status.Phase), HealthError
+		}
+		return string(o.Status.Phase), HealthOK
+	case *corev1.Event:
+		if o.Type == corev1.EventTypeWarning {
+			return o.Type, HealthWarning
+		}
+		return o.Type, HealthOK
+	case *corev1.Namespace:
+		if o.Status.Phase == corev1.NamespaceTerminating {
+			return string(o.Status.Phase), HealthWarning
+		}
+		return string(o.Status.Phase), HealthOK
+	}
+	return "", HealthUnknown
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Scope delimita o que o dashboard enxerga: o namespace e os seletores no
+// formato do kubectl (-l e --field-selector). Seletores vazios aceitam tudo.
+// Namespaces, quando não vazio, substitui Namespace por um conjunto; os
+// builders consultam cada um e juntam os resultados, e os Backends só olham
+// Namespace.
+type Scope struct {
+	Namespace  string
+	Namespaces []string
+	Labels     string
+	Fields     string
+}
+
+// Validate confere a sintaxe dos seletores antes de aplicá-los ao dashboard.
+func (s Scope) Validate() error {
+	if _, err := labels.Parse(s.Labels); err != nil {
+		return err
+	}
+	if _, err := fields.ParseSelector(s.Fields); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HasSelector diz se há algum seletor além do namespace.
+func (s Scope) HasSelector() bool {
+	return strings.TrimSpace(s.Labels) != "" || strings.TrimSpace(s.Fields) != ""
+}
+
+// Selector descreve os seletores como flags do kubectl, para o cabeçalho.
+func (s Scope) Selector() string {
+	var parts []string
+	if l := strings.TrimSpace(s.Labels); l != "" {
+		parts = append(parts, "-l "+l)
+	}
+	if f := strings.TrimSpace(s.Fields); f != "" {
+		parts = append(parts, "--field-selector "+f)
+	}
+	return strings.Join(parts, " ")
+}
+
+// targets são os namespaces a consultar; "" significa todos.
+func (s Scope) targets() []string {
+	if len(s.Namespaces) > 0 {
+		return s.Namespaces
+	}
+	return []string{namespaceTarget(s.Namespace)}
+}
+
+// single devolve o escopo restrito a um namespace, com os mesmos seletores.
+func (s Scope) single(ns string) Scope {
+	s.Namespace = ns
+	s.Namespaces = nil
+	return s
+}
+
+// multiNamespace diz se as listagens misturam namespaces (coluna NAMESPACE).
+func (s Scope) multiNamespace() bool {
+	targets := s.targets()
+	return len(targets) > 1 || targets[0] == metav1.NamespaceAll
+}
+
+func (s Scope) listOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: strings.TrimSpace(s.Labels),
+		FieldSelector: strings.TrimSpace(s.Fields),
+	}
+}
+
+// key identifica o escopo no cache de polling.
+func (s Scope) key() string {
+	return strings.Join(s.targets(), ",") + "|" + strings.TrimSpace(s.Labels) + "|" + strings.TrimSpace(s.Fields)
+}
+
+// matchLabels filtra objetos já listados (cache dos informers) pelo seletor de labels.
+func (s Scope) matchLabels(objs []runtime.Object) ([]runtime.Object, error) {
+	if strings.TrimSpace(s.Labels) == "" {
+		return objs, nil
+	}
+	sel, err := labels.Parse(s.Labels)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		if m, ok := obj.(metav1.Object); ok && sel.Matches(labels.Set(m.GetLabels())) {
+			kept = append(kept, obj)
+		}
+	}
+	return kept, nil
+}
+
+// unsupportedField reconhece a recusa do apiserver a um campo que o kind não
+// indexa (ex.: status.phase em deployments): nenhum objeto desse kind casa.
+func unsupportedField(s Scope, err error) bool {
+	return strings.TrimSpace(s.Fields) != "" && apierrors.IsBadRequest(err)
+}
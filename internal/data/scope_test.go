@@ -0,0 +1,84 @@
+package data
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestScopeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   Scope
+		wantErr bool
+	}{
+		{"empty", Scope{}, false},
+		{"labels", Scope{Labels: "app=web,tier!=db"}, false},
+		{"set-based labels", Scope{Labels: "env in (prod,stage),!canary"}, false},
+		{"fields", Scope{Fields: "status.phase=Running,spec.nodeName!=n1"}, false},
+		{"both", Scope{Labels: "app=web", Fields: "metadata.name=x"}, false},
+		{"bad labels", Scope{Labels: "=web"}, true},
+		{"bad label value", Scope{Labels: "app=web server"}, true},
+		{"bad fields", Scope{Fields: "status.phase"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.scope.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScopeSelector(t *testing.T) {
+	s := Scope{Labels: " app=web ", Fields: "status.phase=Running"}
+	if got, want := s.Selector(), "-l app=web --field-selector status.phase=Running"; got != want {
+		t.Errorf("Selector() = %q, want %q", got, want)
+	}
+	if (Scope{Namespace: "default"}).HasSelector() {
+		t.Error("HasSelector() without selectors = true")
+	}
+}
+
+// listBackend devolve objs (ou errs) por kind e registra os kinds consultados.
+type listBackend struct {
+	objs   map[string][]runtime.Object
+	errs   map[string]error
+	listed []string
+}
+
+func (b *listBackend) List(kind string, _ Scope) ([]runtime.Object, error) {
+	b.listed = append(b.listed, kind)
+	return b.objs[kind], b.errs[kind]
+}
+
+func (b *listBackend) PodMetrics(string) ([]PodMetrics, error) {
+	return nil, nil
+}
+
+func TestBuildEventsWithSelector(t *testing.T) {
+	event := func(name string, uid types.UID) runtime.Object {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", UID: uid},
+		}
+	}
+	b := &listBackend{objs: map[string][]runtime.Object{
+		"pods":   {&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "pod-web"}}},
+		"events": {event("in-scope", "pod-web"), event("other", "pod-api")},
+	}}
+	table := BuildEvents(Scope{Namespace: "default", Labels: "app=web"}, b)
+
+	if len(table.Rows) != 1 || table.Rows[0].Name != "in-scope" {
+		t.Errorf("rows = %+v, want only the in-scope event", table.Rows)
+	}
+	for _, kind := range b.listed {
+		if kind != "events" && !slices.Contains(eventKinds, kind) {
+			t.Errorf("BuildEvents listed %q", kind)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package data
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Tipos de coluna que não podem ser comparados como texto.
+var (
+	durationColumns = map[string]bool{"AGE": true, "LAST SEEN": true, "DURATION": true, "LAST SCHEDULE": true}
+	quantityColumns = map[string]bool{"CAPACITY": true, "CPU(cores)": true, "MEMORY(bytes)": true}
+	ratioColumns    = map[string]bool{"READY": true, "COMPLETIONS": true}
+	numberColumns   = map[string]bool{
+		"RESTARTS": true, "DATA": true, "SECRETS": true, "DESIRED": true,
+		"CURRENT": true, "UP-TO-DATE": true, "AVAILABLE": true, "ACTIVE": true,
+	}
+)
+
+// SortRows ordena t.Rows pela coluna de nome column; devolve false se a tabela não a tem.
+func SortRows(t *Table, column string, desc bool) bool {
+	col := -1
+	for i, h := range t.Header {
+		if h == column {
+			col = i
+			break
+		}
+	}
+	if col == -1 {
+		return false
+	}
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		c := compareColumn(column, &t.Rows[i], &t.Rows[j], col)
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	return true
+}
+
+func compareColumn(column string, a, b *Row, col int) int {
+	av, bv := cell(a, col), cell(b, col)
+	switch {
+	case column == "AGE" && !a.Created.IsZero() && !b.Created.IsZero():
+		// mais novo = menor idade
+		return b.Created.Compare(a.Created)
+	case durationColumns[column]:
+		return compareFloat(parseHumanDuration(av), parseHumanDuration(bv))
+	case quantityColumns[column]:
+		return compareFloat(parseQuantity(av), parseQuantity(bv))
+	case ratioColumns[column]:
+		an, ad := parseRatio(av)
+		bn, bd := parseRatio(bv)
+		if c := compareFloat(an, bn); c != 0 {
+			return c
+		}
+		return compareFloat(ad, bd)
+	case numberColumns[column]:
+		return compareFloat(parseLeadingInt(av), parseLeadingInt(bv))
+	}
+	return strings.Compare(av, bv)
+}
+
+func cell(r *Row, col int) string {
+	if col < len(r.Columns) {
+		return r.Columns[col]
+	}
+	return ""
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseHumanDuration lê o formato de duration.HumanDuration ("3y45d", "5h2m", "45s").
+// Valores sem duração ("<none>", "<unknown>", vazio) ficam antes de tudo.
+func parseHumanDuration(s string) float64 {
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+	var total time.Duration
+	num := 0
+	digits := false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			num = num*10 + int(ch-'0')
+			digits = true
+		case digits && units[ch] != 0:
+			total += time.Duration(num) * units[ch]
+			num, digits = 0, false
+		default:
+			return -1
+		}
+	}
+	if total == 0 && !strings.HasSuffix(s, "s") {
+		return -1
+	}
+	return total.Seconds()
+}
+
+func parseQuantity(s string) float64 {
+	q, err := resource.ParseQuantity(strings.TrimSpace(s))
+	if err != nil {
+		return -1
+	}
+	return q.AsApproximateFloat64()
+}
+
+func parseRatio(s string) (float64, float64) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return -1, -1
+	}
+	num, den, _ := strings.Cut(fields[0], "/")
+	return parseLeadingInt(num), parseLeadingInt(den)
+}
+
+// parseLeadingInt aceita "5" e "5 (3m ago)" (RESTARTS).
+func parseLeadingInt(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return -1
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return -1
+	}
+	return float64(n)
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func tableOf(column string, values ...string) Table {
+	t := Table{Header: []string{"NAME", column}}
+	for i, v := range values {
+		t.Rows = append(t.Rows, Row{Name: string(rune('a' + i)), Columns: []string{string(rune('a' + i)), v}})
+	}
+	return t
+}
+
+func columnValues(t Table, col int) []string {
+	var out []string
+	for _, r := range t.Rows {
+		out = append(out, r.Columns[col])
+	}
+	return out
+}
+
+func TestSortRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		desc   bool
+		in     []string
+		want   []string
+	}{
+		{"duration", "LAST SEEN", false, []string{"2d", "45s", "5h2m", "<unknown>", "3y45d", "10m"}, []string{"<unknown>", "45s", "10m", "5h2m", "2d", "3y45d"}},
+		{"duration desc", "DURATION", true, []string{"90s", "2m", "1h"}, []string{"1h", "2m", "90s"}},
+		{"quantity", "MEMORY(bytes)", false, []string{"1Gi", "512Mi", "100Ki", "2G"}, []string{"100Ki", "512Mi", "1Gi", "2G"}},
+		{"cpu quantity", "CPU(cores)", false, []string{"1", "250m", "1500m", "5m"}, []string{"5m", "250m", "1", "1500m"}},
+		{"ratio by ready then total", "READY", false, []string{"2/3", "1/1", "0/1", "2/2"}, []string{"0/1", "1/1", "2/2", "2/3"}},
+		{"number with suffix", "RESTARTS", false, []string{"10", "2 (3m ago)", "0"}, []string{"0", "2 (3m ago)", "10"}},
+		{"text", "STATUS", false, []string{"Running", "Pending", "Failed"}, []string{"Failed", "Pending", "Running"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tableOf(tt.column, tt.in...)
+			if !SortRows(&table, tt.column, tt.desc) {
+				t.Fatalf("SortRows(%q) = false", tt.column)
+			}
+			if got := columnValues(table, 1); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortRowsAgeUsesCreated(t *testing.T) {
+	now := time.Now()
+	table := Table{Header: []string{"NAME", "AGE"}, Rows: []Row{
+		{Name: "old", Created: now.Add(-48 * time.Hour), Columns: []string{"old", "2d"}},
+		{Name: "new", Created: now.Add(-time.Minute), Columns: []string{"new", "1m"}},
+		{Name: "mid", Created: now.Add(-time.Hour), Columns: []string{"mid", "60m"}},
+	}}
+	SortRows(&table, "AGE", false)
+	if got := columnValues(table, 0); !slices.Equal(got, []string{"new", "mid", "old"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestSortRowsMissingColumn(t *testing.T) {
+	table := tableOf("STATUS", "b", "a")
+	if SortRows(&table, "AGE", false) {
+		t.Fatal("SortRows on a missing column = true")
+	}
+	if got := columnValues(table, 1); !slices.Equal(got, []string{"b", "a"}) {
+		t.Errorf("rows reordered: %v", got)
+	}
+}
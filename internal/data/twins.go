@@ -0,0 +1,263 @@
+package data
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TwinSide é um dos lados do modo twins: um escopo servido por um backend
+// (o mesmo cluster com outro namespace, ou outro contexto).
+type TwinSide struct {
+	Label   string
+	Scope   Scope
+	Backend Backend
+}
+
+// TwinState classifica um objeto na comparação.
+type TwinState int
+
+const (
+	TwinSame TwinState = iota
+	TwinChanged
+	TwinOnlyLeft
+	TwinOnlyRight
+)
+
+// TwinDiff é um objeto que difere entre os lados ou só existe em um deles.
+type TwinDiff struct {
+	Kind    string
+	Name    string
+	State   TwinState
+	Changes []string // "image web: nginx:1.25 → nginx:1.26", ...
+}
+
+// TwinReport alinha os lados linha a linha: Left[i] e Right[i] têm o mesmo
+// kind e, em cada posição, o mesmo nome; o lado sem o objeto recebe uma linha
+// TwinAbsent. Health das linhas indica o TwinState (OK, Warning ou Error).
+type TwinReport struct {
+	Left, Right []Table
+	Diffs       []TwinDiff
+	Counts      map[TwinState]int
+}
+
+// twinKinds são os kinds cujos nomes são estáveis entre ambientes (pods e
+// ReplicaSets têm sufixos gerados e não alinham).
+var twinKinds = []string{"deploy", "sts", "ds", "cronjobs", "svc", "ingress", "configmaps"}
+
+// TwinAbsent marca (em Columns e Status) a linha do lado que não tem o objeto.
+const TwinAbsent = "<ausente>"
+
+// CompareTwins lista os mesmos kinds nos dois lados e alinha os objetos pelo
+// nome. Se um lado abrange vários namespaces, vale o primeiro objeto de cada nome.
+func CompareTwins(left, right TwinSide) TwinReport {
+	report := TwinReport{Counts: map[TwinState]int{}}
+	for _, kind := range twinKinds {
+		lobjs, lerr := listScope(left.Backend, kind, left.Scope)
+		robjs, rerr := listScope(right.Backend, kind, right.Scope)
+		if lerr != nil || rerr != nil {
+			report.Left = append(report.Left, twinErrTable(kind, lerr, right.Label))
+			report.Right = append(report.Right, twinErrTable(kind, rerr, left.Label))
+			continue
+		}
+		lby, rby := byName(lobjs), byName(robjs)
+		names := make([]string, 0, len(lby)+len(rby))
+		for name := range lby {
+			names = append(names, name)
+		}
+		for name := range rby {
+			if _, ok := lby[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+
+		header := printers[kind].header
+		lt := Table{Kind: kind, Header: header}
+		rt := Table{Kind: kind, Header: header}
+		for _, name := range names {
+			lobj, inLeft := lby[name]
+			robj, inRight := rby[name]
+			diff := TwinDiff{Kind: kind, Name: name}
+			switch {
+			case !inRight:
+				diff.State = TwinOnlyLeft
+			case !inLeft:
+				diff.State = TwinOnlyRight
+			default:
+				diff.Changes = diffFacts(twinFacts(lobj), twinFacts(robj))
+				if len(diff.Changes) > 0 {
+					diff.State = TwinChanged
+				}
+			}
+			report.Counts[diff.State]++
+			if diff.State != TwinSame {
+				report.Diffs = append(report.Diffs, diff)
+			}
+			lt.Rows = append(lt.Rows, twinRow(kind, name, left.Scope, lobj, diff.State))
+			rt.Rows = append(rt.Rows, twinRow(kind, name, right.Scope, robj, diff.State))
+		}
+		report.Left = append(report.Left, lt)
+		report.Right = append(report.Right, rt)
+	}
+	return report
+}
+
+// twinErrTable mantém o alinhamento quando só um lado falha: o outro mostra o motivo.
+func twinErrTable(kind string, err error, otherLabel string) Table {
+	if err == nil {
+		err = fmt.Errorf("sem comparação: falha ao listar em %s", otherLabel)
+	}
+	return Table{Kind: kind, Err: err}
+}
+
+func byName(objs []runtime.Object) map[string]runtime.Object {
+	out := map[string]runtime.Object{}
+	for _, obj := range objs {
+		m, ok := obj.(metav1.Object)
+		if !ok {
+			continue
+		}
+		if _, dup := out[m.GetName()]; !dup {
+			out[m.GetName()] = obj
+		}
+	}
+	return out
+}
+
+func twinRow(kind, name string, s Scope, obj runtime.Object, state TwinState) Row {
+	var row Row
+	if obj != nil {
+		row = newTable(kind, []runtime.Object{obj}, false).Rows[0]
+	} else {
+		row = Row{Kind: kind, Namespace: s.Namespace, Name: name, Columns: []string{name, TwinAbsent}, Status: TwinAbsent}
+	}
+	switch state {
+	case TwinSame:
+		row.Health = HealthOK
+	case TwinChanged:
+		row.Health = HealthWarning
+	default:
+		row.Health = HealthError
+	}
+	return row
+}
+
+// twinFacts extrai o que importa comparar: imagens, limits e réplicas dos
+// workloads, chaves (e valores, por hash) de ConfigMaps, forma de Services
+// e hosts de Ingresses.
+func twinFacts(obj runtime.Object) map[string]string {
+	f := map[string]string{}
+	var spec *corev1.PodSpec
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		spec = &o.Spec.Template.Spec
+		f["replicas"] = strconv.Itoa(int(int32Value(o.Spec.Replicas)))
+	case *appsv1.StatefulSet:
+		spec = &o.Spec.Template.Spec
+		f["replicas"] = strconv.Itoa(int(int32Value(o.Spec.Replicas)))
+	case *appsv1.DaemonSet:
+		spec = &o.Spec.Template.Spec
+	case *batchv1.CronJob:
+		spec = &o.Spec.JobTemplate.Spec.Template.Spec
+		f["schedule"] = o.Spec.Schedule
+	case *corev1.ConfigMap:
+		for k, v := range o.Data {
+			f["key "+k] = shortHash([]byte(v))
+		}
+		for k, v := range o.BinaryData {
+			f["key "+k] = shortHash(v)
+		}
+	case *corev1.Service:
+		row := serviceRow(o)
+		f["type"] = row[1]
+		f["ports"] = row[4]
+	case *networkingv1.Ingress:
+		f["hosts"] = ingressRow(o)[2]
+	}
+	if spec != nil {
+		containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+		for _, c := range containers {
+			f["image "+c.Name] = c.Image
+			f["limits "+c.Name] = formatResources(c.Resources.Limits)
+		}
+	}
+	return f
+}
+
+// diffFacts descreve cada fato diferente entre os lados, em ordem estável.
+func diffFacts(left, right map[string]string) []string {
+	keys := make([]string, 0, len(left)+len(right))
+	for k := range left {
+		keys = append(keys, k)
+	}
+	for k := range right {
+		if _, ok := left[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, k := range keys {
+		lv, inLeft := left[k]
+		rv, inRight := right[k]
+		switch {
+		case inLeft && inRight && lv == rv:
+		case strings.HasPrefix(k, "key ") && inLeft && inRight:
+			changes = append(changes, k+": valor difere")
+		case strings.HasPrefix(k, "key "):
+			changes = append(changes, k+": "+presence(inLeft)+" → "+presence(inRight))
+		default:
+			changes = append(changes, k+": "+orAbsent(lv, inLeft)+" → "+orAbsent(rv, inRight))
+		}
+	}
+	return changes
+}
+
+func presence(ok bool) string {
+	if ok {
+		return "presente"
+	}
+	return "ausente"
+}
+
+func orAbsent(v string, ok bool) string {
+	if !ok {
+		return TwinAbsent
+	}
+	return v
+}
+
+func formatResources(list corev1.ResourceList) string {
+	if len(list) == 0 {
+		return "<none>"
+	}
+	names := make([]string, 0, len(list))
+	for name := range list {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		q := list[corev1.ResourceName(name)]
+		parts = append(parts, name+"="+q.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+func shortHash(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:12]
+}
@@ -0,0 +1,103 @@
+package data
+
+import (
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDiffFacts(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right map[string]string
+		want        []string
+	}{
+		{"same", map[string]string{"replicas": "2"}, map[string]string{"replicas": "2"}, nil},
+		{"changed", map[string]string{"image web": "nginx:1.25"}, map[string]string{"image web": "nginx:1.26"}, []string{"image web: nginx:1.25 → nginx:1.26"}},
+		{"only left", map[string]string{"image sidecar": "envoy"}, map[string]string{}, []string{"image sidecar: envoy → <ausente>"}},
+		{"only right", map[string]string{}, map[string]string{"schedule": "*/5 * * * *"}, []string{"schedule: <ausente> → */5 * * * *"}},
+		{"configmap value", map[string]string{"key a": "h1"}, map[string]string{"key a": "h2"}, []string{"key a: valor difere"}},
+		{"configmap key", map[string]string{"key a": "h1"}, map[string]string{"key b": "h1"}, []string{"key a: presente → ausente", "key b: ausente → presente"}},
+		{"sorted", map[string]string{"replicas": "1", "image a": "x"}, map[string]string{"replicas": "3", "image a": "y"}, []string{"image a: x → y", "replicas: 1 → 3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffFacts(tt.left, tt.right); !slices.Equal(got, tt.want) {
+				t.Errorf("diffFacts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func twinDeploy(name, image string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: image}},
+			}},
+		},
+	}
+}
+
+func TestCompareTwins(t *testing.T) {
+	left := &listBackend{objs: map[string][]runtime.Object{
+		"deploy": {twinDeploy("api", "api:1", 2), twinDeploy("web", "web:1", 1), twinDeploy("worker", "worker:1", 1)},
+	}}
+	right := &listBackend{objs: map[string][]runtime.Object{
+		"deploy":     {twinDeploy("api", "api:1", 2), twinDeploy("web", "web:2", 3), twinDeploy("cron", "cron:1", 1)},
+		"configmaps": {&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings"}}},
+	}}
+	report := CompareTwins(TwinSide{Label: "left", Backend: left}, TwinSide{Label: "right", Backend: right})
+
+	if len(report.Left) != len(report.Right) {
+		t.Fatalf("tables: left %d, right %d", len(report.Left), len(report.Right))
+	}
+	for i := range report.Left {
+		l, r := report.Left[i], report.Right[i]
+		if l.Kind != r.Kind || len(l.Rows) != len(r.Rows) {
+			t.Fatalf("table %d not aligned: %s/%d vs %s/%d", i, l.Kind, len(l.Rows), r.Kind, len(r.Rows))
+		}
+		for j := range l.Rows {
+			if l.Rows[j].Name != r.Rows[j].Name {
+				t.Errorf("%s row %d: %q vs %q", l.Kind, j, l.Rows[j].Name, r.Rows[j].Name)
+			}
+		}
+	}
+
+	wantCounts := map[TwinState]int{TwinSame: 1, TwinChanged: 1, TwinOnlyLeft: 1, TwinOnlyRight: 2}
+	for state, n := range wantCounts {
+		if report.Counts[state] != n {
+			t.Errorf("Counts[%d] = %d, want %d", state, report.Counts[state], n)
+		}
+	}
+
+	var web *TwinDiff
+	for i := range report.Diffs {
+		if report.Diffs[i].Name == "web" {
+			web = &report.Diffs[i]
+		}
+	}
+	if web == nil {
+		t.Fatal("no diff for web")
+	}
+	want := []string{"image app: web:1 → web:2", "replicas: 1 → 3"}
+	if web.State != TwinChanged || !slices.Equal(web.Changes, want) {
+		t.Errorf("web diff = %+v, want changes %q", *web, want)
+	}
+
+	deploys := report.Right[0]
+	if deploys.Kind != "deploy" {
+		t.Fatalf("first table kind = %q", deploys.Kind)
+	}
+	for _, r := range deploys.Rows {
+		if r.Name == "worker" && r.Status != TwinAbsent {
+			t.Errorf("worker on the right: status %q, want %q", r.Status, TwinAbsent)
+		}
+	}
+}
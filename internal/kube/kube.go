@@ -0,0 +1,83 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Kubeconfig é o kubeconfig mesclado com as mesmas regras do kubectl
+// (todos os arquivos de KUBECONFIG, ou ~/.kube/config).
+type Kubeconfig struct {
+	rules *clientcmd.ClientConfigLoadingRules
+	raw   *clientcmdapi.Config
+}
+
+// Context resume uma entrada de "contexts" do kubeconfig.
+type Context struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string
+}
+
+// Session é a conexão com um contexto: config REST e clientset já montados.
+type Session struct {
+	Context
+	Server    string // URL do apiserver; identifica o cluster nas preferências
+	Config    *rest.Config
+	Clientset *kubernetes.Clientset
+}
+
+func Load() (*Kubeconfig, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	raw, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Kubeconfig{rules: rules, raw: raw}, nil
+}
+
+// Current é o current-context do kubeconfig.
+func (k *Kubeconfig) Current() string {
+	return k.raw.CurrentContext
+}
+
+// Contexts lista todos os contextos, em ordem alfabética.
+func (k *Kubeconfig) Contexts() []Context {
+	out := make([]Context, 0, len(k.raw.Contexts))
+	for name, c := range k.raw.Contexts {
+		out = append(out, Context{Name: name, Cluster: c.Cluster, User: c.AuthInfo, Namespace: c.Namespace})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Connect monta o clientset do contexto name ("" = current-context).
+func (k *Kubeconfig) Connect(name string) (*Session, error) {
+	if name == "" {
+		name = k.raw.CurrentContext
+	}
+	c, ok := k.raw.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("contexto %q não existe no kubeconfig", name)
+	}
+	cfg, err := clientcmd.NewNonInteractiveClientConfig(*k.raw, name, &clientcmd.ConfigOverrides{}, k.rules).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		Context:   Context{Name: name, Cluster: c.Cluster, User: c.AuthInfo, Namespace: c.Namespace},
+		Server:    cfg.Host,
+		Config:    cfg,
+		Clientset: clientset,
+	}, nil
+}
@@ -5,6 +5,7 @@ const (
 	Header = "[skyblue::b]"
 	Red    = "[red]"
 	Green  = "[green]"
+	Yellow = "[yellow]"
 	Reset  = "[-:-:-]"
 )
 
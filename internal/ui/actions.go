@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ktwins/internal/data"
+	"ktwins/internal/kube"
+)
+
+// actionTarget é o objeto selecionado quando se abre o menu de ações [x].
+type actionTarget struct {
+	kind, name, namespace string
+	uid                   string
+	session               *kube.Session // do lado de onde veio a linha (twins)
+	backend               data.Backend
+}
+
+func (t actionTarget) ref() string {
+	return t.kind + "/" + t.name
+}
+
+// rowAction é uma entrada do menu de ações; kinds limita onde aparece (nil =
+// qualquer linha).
+type rowAction struct {
+	name, help string
+	kinds      map[string]bool
+	run        func(d *Dashboard, t actionTarget)
+}
+
+var rowActions = []rowAction{
+	{"scale", "muda o número de réplicas", data.ScaleKinds, (*Dashboard).scaleWorkload},
+	{"restart", "rollout restart: recria os pods aos poucos", data.RestartKinds, (*Dashboard).restartWorkload},
+	{"pause", "pausa o rollout (mudanças no template esperam)", data.PauseKinds, func(d *Dashboard, t actionTarget) { d.pauseWorkload(t, true) }},
+	{"resume", "retoma o rollout pausado", data.PauseKinds, func(d *Dashboard, t actionTarget) { d.pauseWorkload(t, false) }},
+	{"history", "revisões do rollout, diff entre elas e rollback", data.HistoryKinds, (*Dashboard).openHistory},
+	{"delete", "apaga o objeto (política de cascata, prazo, force)", nil, (*Dashboard).deleteObject},
+}
+
+// selectedTarget é a linha selecionada como alvo de ação; ok é falso sem
+// seleção, numa linha <ausente> do modo twins ou numa linha que não é um
+// objeto (o ALL do painel de namespaces não tem nome). Kinds cluster-scoped
+// ficam sem namespace.
+func (d *Dashboard) selectedTarget() (t actionTarget, ok bool) {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent || strings.TrimSpace(row.Name) == "" {
+		return t, false
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(row.Namespace) != "" {
+		nsUse = row.Namespace
+	}
+	if data.ClusterScoped(row.Kind) {
+		nsUse = ""
+	}
+	return actionTarget{kind: row.Kind, name: row.Name, namespace: nsUse, uid: row.UID, session: d.selectionSession(), backend: d.selectionBackend()}, true
+}
+
+// openActionsSelected abre o menu de ações do objeto selecionado.
+func (d *Dashboard) openActionsSelected() {
+	t, ok := d.selectedTarget()
+	if !ok {
+		return
+	}
+	var actions []rowAction
+	for _, a := range rowActions {
+		if a.kinds == nil || a.kinds[t.kind] {
+			actions = append(actions, a)
+		}
+	}
+	d.openPicker("ACTIONS "+t.ref(), "", func(query string) data.Table {
+		var rows []data.Row
+		for _, a := range actions {
+			rows = append(rows, data.Row{Kind: "action", Name: a.name, Columns: []string{a.name, a.help}})
+		}
+		return data.Table{
+			Kind:   "action",
+			Header: []string{"ACTION", "DESCRIPTION"},
+			Rows:   rankRows(query, rows, func(r data.Row) string { return r.Name }, nil),
+		}
+	}, func(row data.Row) {
+		for _, a := range actions {
+			if a.name == row.Name {
+				a.run(d, t)
+			}
+		}
+	})
+}
+
+// confirmAction pede a confirmação digitada (o nome do objeto) e roda fn em
+// segundo plano; done é a mensagem de sucesso.
+func (d *Dashboard) confirmAction(t actionTarget, action, done string, fn func(ctx context.Context) error) {
+	d.openConfirm(strings.ToUpper(action), actionMessage(action, t), t.name, nil, func() {
+		go func() {
+			if err := fn(context.Background()); err != nil {
+				d.showInfo(fmt.Sprintf("Erro em %s: %v", t.ref(), err))
+				return
+			}
+			d.showInfo(done)
+			d.scheduleUpdate() // a linha muda já, sem esperar o próximo ciclo
+		}()
+	})
+}
+
+// scaleWorkload pergunta as réplicas (partindo das atuais) e confirma.
+func (d *Dashboard) scaleWorkload(t actionTarget) {
+	c := t.session.Clientset
+	go func() {
+		current, err := data.Replicas(context.Background(), c, t.kind, t.namespace, t.name)
+		if err != nil {
+			d.showInfo(fmt.Sprintf("Erro em %s: %v", t.ref(), err))
+			return
+		}
+		_ = d.app.QueueUpdateDraw(func() {
+			label := fmt.Sprintf("réplicas de %s (hoje %d): ", t.ref(), current)
+			d.openPrompt(label, strconv.Itoa(int(current)), nil, func(text string, accepted bool) {
+				if !accepted {
+					return
+				}
+				n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 32)
+				if err != nil || n < 0 {
+					go d.showInfo(fmt.Sprintf("Réplicas inválidas: %q", text))
+					return
+				}
+				action := fmt.Sprintf("scale %s: %d → %d réplicas", t.ref(), current, n)
+				d.confirmAction(t, action, fmt.Sprintf("%s escalado para %d", t.ref(), n), func(ctx context.Context) error {
+					return data.Scale(ctx, c, t.kind, t.namespace, t.name, int32(n))
+				})
+			})
+		})
+	}()
+}
+
+func (d *Dashboard) restartWorkload(t actionTarget) {
+	c := t.session.Clientset
+	d.confirmAction(t, "rollout restart "+t.ref(), t.ref()+" reiniciando", func(ctx context.Context) error {
+		return data.RolloutRestart(ctx, c, t.kind, t.namespace, t.name)
+	})
+}
+
+func (d *Dashboard) pauseWorkload(t actionTarget, paused bool) {
+	c := t.session.Clientset
+	verb, done := "rollout resume ", " retomado"
+	if paused {
+		verb, done = "rollout pause ", " pausado"
+	}
+	d.confirmAction(t, verb+t.ref(), t.ref()+done, func(ctx context.Context) error {
+		return data.SetRolloutPaused(ctx, c, t.kind, t.namespace, t.name, paused)
+	})
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"ktwins/internal/theme"
+)
+
+// openConfirm pede confirmação digitada para uma ação que muda o cluster:
+// message descreve a ação e só expect, digitado exatamente, confirma; Esc
+// desiste. options são campos extras (política, prazo...) lidos por confirm;
+// Tab anda entre eles.
+func (d *Dashboard) openConfirm(title, message, expect string, options []tview.FormItem, confirm func()) {
+	if d.confirmOpen {
+		return
+	}
+	restore := d.app.GetFocus()
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetText(message)
+	label := fmt.Sprintf("digite %q para confirmar: ", expect)
+	input := tview.NewInputField().SetLabel(label)
+	form := tview.NewForm().
+		SetItemPadding(0).
+		SetLabelColor(tcell.ColorSkyblue).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	form.SetBorderPadding(1, 0, 0, 0)
+	for _, item := range options {
+		form.AddFormItem(item)
+	}
+	form.AddFormItem(input)
+	form.SetFocus(len(options)) // começa no campo de confirmação
+
+	closeConfirm := func() {
+		d.confirmOpen = false
+		d.pages.RemovePage("confirm")
+		if restore != nil {
+			d.app.SetFocus(restore)
+		}
+	}
+	form.SetCancelFunc(closeConfirm)
+	input.SetChangedFunc(func(string) {
+		input.SetLabel(label)
+	})
+	input.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() != tcell.KeyEnter {
+			return ev
+		}
+		if input.GetText() != expect {
+			input.SetLabel("não confere, " + label)
+			return nil
+		}
+		closeConfirm()
+		confirm()
+		return nil
+	})
+
+	lines := 1
+	for _, r := range message {
+		if r == '\n' {
+			lines++
+		}
+	}
+	hint := "Enter confirma, Esc desiste"
+	if len(options) > 0 {
+		hint = "Tab muda de campo, " + hint
+	}
+	box := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, false).
+		AddItem(form, len(options)+2, 0, true)
+	box.SetBorder(true).SetBorderColor(tcell.ColorRed).SetTitle(" " + title + " (" + hint + ") ")
+	popup := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(box, min(lines+len(options)+6, 30), 0, true).
+			AddItem(nil, 0, 1, false), 90, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	d.confirmOpen = true
+	d.pages.AddPage("confirm", popup, true, true)
+	d.app.SetFocus(form)
+}
+
+// actionMessage é o cabeçalho comum das confirmações: o que será feito, em
+// qual namespace (ou cluster-scoped) e em qual contexto.
+func actionMessage(action string, t actionTarget) string {
+	namespace := displayNS(t.namespace)
+	if data.ClusterScoped(t.kind) {
+		namespace = "cluster-scoped"
+	}
+	return fmt.Sprintf("%s%s%s\n\n%snamespace:%s %s\n%scontexto:%s  %s",
+		theme.Yellow, tview.Escape(action), theme.Reset,
+		theme.Header, theme.Reset, tview.Escape(namespace),
+		theme.Header, theme.Reset, tview.Escape(t.session.Name))
+}
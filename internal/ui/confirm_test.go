@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ktwins/internal/kube"
+)
+
+func TestActionMessageNamespace(t *testing.T) {
+	session := &kube.Session{}
+	session.Name = "prod"
+	tests := []struct {
+		kind, namespace, want string
+	}{
+		{"deploy", "shop", "namespace:[-:-:-] shop"},
+		{"pods", "", "namespace:[-:-:-] ALL"},
+		{"nodes", "", "namespace:[-:-:-] cluster-scoped"},
+		{"ns", "default", "namespace:[-:-:-] cluster-scoped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			got := actionMessage("delete", actionTarget{kind: tt.kind, name: "x", namespace: tt.namespace, session: session})
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("actionMessage = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/types"
+	"ktwins/internal/data"
+	"ktwins/internal/theme"
+)
+
+const maxOwnedShown = 25 // dependentes listados na confirmação; o resto vira "+N"
+
+func (d *Dashboard) deleteSelected() {
+	if t, ok := d.selectedTarget(); ok {
+		d.deleteObject(t)
+	}
+}
+
+// deleteObject procura os dependentes (ownerReferences) e abre a confirmação
+// com política de propagação, prazo e, para pods, force.
+func (d *Dashboard) deleteObject(t actionTarget) {
+	go func() {
+		owned, forbidden, err := data.OwnedObjects(t.backend, t.kind, t.namespace, types.UID(t.uid))
+		_ = d.app.QueueUpdateDraw(func() {
+			d.confirmDelete(t, owned, forbidden, err)
+		})
+	}()
+}
+
+func (d *Dashboard) confirmDelete(t actionTarget, owned []data.OwnedObject, forbidden []string, ownedErr error) {
+	propagation := tview.NewDropDown().SetLabel("propagation: ").SetOptions(data.Propagations, nil).SetCurrentOption(0)
+	grace := tview.NewInputField().SetLabel("grace period (s): ").SetPlaceholder("padrão do objeto").
+		SetAcceptanceFunc(tview.InputFieldInteger).SetFieldWidth(10)
+	options := []tview.FormItem{propagation, grace}
+	var force *tview.Checkbox
+	if t.kind == "pods" {
+		force = tview.NewCheckbox().SetLabel("force (preso em Terminating): ")
+		options = append(options, force)
+	}
+
+	var b strings.Builder
+	b.WriteString(actionMessage("delete "+t.ref(), t))
+	b.WriteString("\n\n")
+	if len(forbidden) > 0 {
+		fmt.Fprintf(&b, "%sDependentes parciais: sem permissão em %s%s\n", theme.Yellow, strings.Join(forbidden, ", "), theme.Reset)
+	}
+	switch {
+	case t.kind == "ns":
+		fmt.Fprintf(&b, "%sTodos os objetos do namespace %s serão apagados.%s", theme.Red, tview.Escape(t.name), theme.Reset)
+	case ownedErr != nil:
+		fmt.Fprintf(&b, "%sNão foi possível listar os dependentes: %s%s", theme.Yellow, tview.Escape(ownedErr.Error()), theme.Reset)
+	case len(owned) == 0:
+		b.WriteString("Sem dependentes (ownerReferences).")
+	default:
+		fmt.Fprintf(&b, "%sDependentes (%d)%s, apagados em cascata exceto com orphan:\n", theme.Header, len(owned), theme.Reset)
+		for i, o := range owned {
+			if i == maxOwnedShown {
+				fmt.Fprintf(&b, "  … +%d\n", len(owned)-maxOwnedShown)
+				break
+			}
+			fmt.Fprintf(&b, "%s%s/%s\n", strings.Repeat("  ", o.Depth), o.Kind, tview.Escape(o.Name))
+		}
+	}
+
+	d.openConfirm("DELETE "+strings.ToUpper(t.ref()), strings.TrimSuffix(b.String(), "\n"), t.name, options, func() {
+		opts := data.DeleteOptions{GracePeriod: -1}
+		_, opts.Propagation = propagation.GetCurrentOption()
+		if text := strings.TrimSpace(grace.GetText()); text != "" {
+			if n, err := strconv.Atoi(text); err == nil && n >= 0 {
+				opts.GracePeriod = n
+			}
+		}
+		opts.Force = force != nil && force.IsChecked()
+		go func() {
+			if err := data.Delete(context.Background(), t.session.Config, t.kind, t.name, t.namespace, opts); err != nil {
+				d.showInfo(fmt.Sprintf("Erro em %s: %v", t.ref(), err))
+				return
+			}
+			d.showInfo(fmt.Sprintf("%s apagado (%s)", t.ref(), opts.Propagation))
+			d.scheduleUpdate()
+		}()
+	})
+}
@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"ktwins/internal/data"
+	"ktwins/internal/theme"
+)
+
+const diffContext = 3 // linhas comuns em volta de cada mudança
+
+// editSession é um [E] em andamento: o YAML do objeto num arquivo temporário,
+// editado no $EDITOR e enviado depois de um dry-run no servidor.
+type editSession struct {
+	kind, name, namespace string
+	config                *rest.Config
+	path                  string
+	original              []byte
+	edited                []byte // o que passou pelo dry-run; [a] aplica exatamente isso
+	ready                 bool   // dry-run ok, [a] aplica
+	keep                  bool   // não apagar o arquivo: guarda a edição depois de um conflito
+}
+
+func (d *Dashboard) openEditSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
+		return
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(row.Namespace) != "" {
+		nsUse = row.Namespace
+	}
+	session := d.selectionSession()
+	e := &editSession{kind: row.Kind, name: row.Name, namespace: nsUse, config: session.Config}
+	go func() {
+		raw, err := data.ManifestYAML(context.Background(), e.config, e.kind, e.name, e.namespace)
+		if err == nil {
+			err = e.writeTemp(raw)
+		}
+		if err != nil {
+			d.showInfo(fmt.Sprintf("Erro ao abrir %s/%s: %v", e.kind, e.name, err))
+			return
+		}
+		_ = d.app.QueueUpdateDraw(func() { d.runEditor(e) })
+	}()
+}
+
+func (e *editSession) writeTemp(raw []byte) error {
+	f, err := os.CreateTemp("", fmt.Sprintf("ktwins-%s-%s-*.yaml", e.kind, e.name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(raw); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	e.path, e.original = f.Name(), raw
+	return nil
+}
+
+func (e *editSession) cleanup() {
+	if !e.keep {
+		os.Remove(e.path)
+	}
+}
+
+// editorCommand segue o kubectl: KUBE_EDITOR, EDITOR ou vi; o valor pode
+// trazer argumentos ("code --wait").
+func editorCommand() []string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// runEditor suspende a TUI enquanto o editor roda no terminal e, se o
+// arquivo mudou, segue para o dry-run. Roda na goroutine da UI.
+func (d *Dashboard) runEditor(e *editSession) {
+	if d.edit == e {
+		d.edit = nil // fecha o modal sem apagar o arquivo
+		d.closeModal()
+	}
+	args := append(editorCommand(), e.path)
+	var runErr error
+	d.app.Suspend(func() {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		runErr = cmd.Run()
+	})
+	edited, err := os.ReadFile(e.path)
+	switch {
+	case runErr != nil:
+		e.cleanup()
+		go d.showInfo(fmt.Sprintf("Editor %s: %v", args[0], runErr))
+	case err != nil:
+		e.cleanup()
+		go d.showInfo(fmt.Sprintf("Erro ao ler %s: %v", e.path, err))
+	case bytes.Equal(edited, e.original) || len(bytes.TrimSpace(edited)) == 0:
+		e.cleanup()
+		go d.showInfo("Edição cancelada, nada mudou")
+	default:
+		e.edited = edited
+		d.dryRunEdit(e)
+	}
+}
+
+// dryRunEdit valida a edição no apiserver e mostra o diff contra o objeto vivo.
+func (d *Dashboard) dryRunEdit(e *editSession) {
+	d.edit = e
+	e.ready = false
+	d.openModal(fmt.Sprintf("EDIT %s/%s", e.kind, e.name), "Dry-run no servidor...")
+	d.modalLogs.SetTitle(e.title())
+	edited := e.edited
+	go func() {
+		result, err := data.UpdateManifest(context.Background(), e.config, e.kind, e.name, e.namespace, edited, true)
+		var body string
+		if err != nil {
+			body = formatEditError(e, err)
+		} else {
+			live, liveErr := data.FetchManifest(context.Background(), e.config, e.kind, e.name, e.namespace)
+			after, parseErr := data.ParseManifest(result)
+			switch {
+			case liveErr != nil:
+				body = tview.Escape(liveErr.Error())
+			case parseErr != nil:
+				body = tview.Escape(parseErr.Error())
+			default:
+				var changes int
+				body, changes = formatManifestDiff(live, after, "atual", "depois do dry-run")
+				if changes == 0 {
+					body = theme.Yellow + "Dry-run ok, mas o objeto resultante é igual ao atual (a mudança não altera spec nem metadata)." + theme.Reset
+				}
+			}
+		}
+		_ = d.app.QueueUpdateDraw(func() {
+			if d.edit != e {
+				return
+			}
+			e.ready = err == nil
+			e.keep = apierrors.IsConflict(err)
+			d.modalLogs.SetText(body)
+			d.modalLogs.ScrollToBeginning()
+			d.modalLogs.SetTitle(e.title())
+		})
+	}()
+}
+
+// applyEdit envia a edição de verdade, depois da confirmação com [a].
+func (d *Dashboard) applyEdit(e *editSession) {
+	e.ready = false
+	d.modalLogs.SetTitle(e.title())
+	d.modalLogs.SetText("Aplicando...")
+	edited := e.edited
+	go func() {
+		_, err := data.UpdateManifest(context.Background(), e.config, e.kind, e.name, e.namespace, edited, false)
+		_ = d.app.QueueUpdateDraw(func() {
+			if d.edit != e {
+				return
+			}
+			if err != nil {
+				e.keep = apierrors.IsConflict(err)
+				d.modalLogs.SetText(formatEditError(e, err))
+				d.modalLogs.ScrollToBeginning()
+				return
+			}
+			d.closeModal()
+			go d.showInfo(fmt.Sprintf("%s/%s atualizado", e.kind, e.name))
+			d.scheduleUpdate()
+		})
+	}()
+}
+
+func (d *Dashboard) handleEditKeys(ev *tcell.EventKey) *tcell.EventKey {
+	e := d.edit
+	if ev.Key() != tcell.KeyRune {
+		return ev
+	}
+	switch ev.Rune() {
+	case 'a':
+		if e.ready {
+			d.applyEdit(e)
+		}
+		return nil
+	case 'e':
+		d.runEditor(e)
+		return nil
+	}
+	return ev
+}
+
+func (e *editSession) title() string {
+	if e.ready {
+		return fmt.Sprintf("EDIT %s/%s  dry-run ok: [a] aplica  [e] edita de novo  (Esc descarta)", e.kind, e.name)
+	}
+	return fmt.Sprintf("EDIT %s/%s  [e] edita de novo  (Esc descarta)", e.kind, e.name)
+}
+
+// formatEditError destaca o conflito de resourceVersion, que pede editar de
+// novo a partir do objeto atual; a edição fica guardada no arquivo.
+func formatEditError(e *editSession, err error) string {
+	msg := tview.Escape(err.Error())
+	if !apierrors.IsConflict(err) {
+		return fmt.Sprintf("%sO servidor recusou a edição:%s\n\n%s\n\n[e] volta ao editor para corrigir.", theme.Red, theme.Reset, msg)
+	}
+	return fmt.Sprintf("%s[::b]CONFLITO:%s %s/%s mudou no cluster depois de aberto para edição (o resourceVersion do arquivo não é mais o atual).\n\n"+
+		"Nada foi aplicado. Feche com Esc e use E de novo para editar a versão atual; a sua edição ficou em %s.\n\n%s",
+		theme.Red, theme.Reset, e.kind, e.name, tview.Escape(e.path), msg)
+}
+
+// formatManifestDiff mostra o diff (YAML, sem managedFields e status) de a
+// para b, só com os trechos que mudam; changes é o total de linhas mudadas.
+func formatManifestDiff(a, b *data.ManifestNode, aLabel, bLabel string) (text string, changes int) {
+	render := func(root *data.ManifestNode) []string {
+		yaml, _, err := (&manifestView{root: root, hide: true}).export()
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return strings.Split(strings.TrimSuffix(yaml, "\n"), "\n")
+	}
+	lines := data.DiffLines(render(a), render(b))
+	show := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == data.DiffSame {
+			continue
+		}
+		changes++
+		for j := max(0, i-diffContext); j <= min(len(lines)-1, i+diffContext); j++ {
+			show[j] = true
+		}
+	}
+	if changes == 0 {
+		return "", 0
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s--- %s%s\n%s+++ %s%s\n", theme.Red, tview.Escape(aLabel), theme.Reset, theme.Green, tview.Escape(bLabel), theme.Reset)
+	for i, l := range lines {
+		if !show[i] {
+			continue
+		}
+		if i > 0 && !show[i-1] {
+			out.WriteString("[gray]…[-]\n")
+		}
+		text := tview.Escape(l.Text)
+		switch l.Op {
+		case data.DiffDel:
+			fmt.Fprintf(&out, "%s- %s%s\n", theme.Red, text, theme.Reset)
+		case data.DiffAdd:
+			fmt.Fprintf(&out, "%s+ %s%s\n", theme.Green, text, theme.Reset)
+		default:
+			fmt.Fprintf(&out, "  %s\n", text)
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n"), changes
+}
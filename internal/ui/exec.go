@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/tools/remotecommand"
+	"ktwins/internal/data"
+	"ktwins/internal/kube"
+)
+
+// openExecSelected abre um shell no pod selecionado [s]; com mais de um
+// container rodando pergunta qual.
+func (d *Dashboard) openExecSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
+		return
+	}
+	if row.Kind != "pods" {
+		go d.showInfo("Shell: selecione um pod")
+		return
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(row.Namespace) != "" {
+		nsUse = row.Namespace
+	}
+	session := d.selectionSession()
+	target := data.LogTarget{Namespace: nsUse, Pod: row.Name}
+	go func() {
+		containers, def, err := data.PodContainers(context.Background(), session.Clientset, nsUse, row.Name)
+		_ = d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				go d.showInfo(fmt.Sprintf("Erro em pods/%s: %v", row.Name, err))
+				return
+			}
+			// só dá para entrar em container rodando
+			var running []data.Container
+			for _, c := range containers {
+				if c.State == "Running" {
+					running = append(running, c)
+				}
+			}
+			switch len(running) {
+			case 0:
+				go d.showInfo(fmt.Sprintf("pods/%s não tem container rodando", row.Name))
+			case 1:
+				target.Container = running[0].Name
+				d.runShell(session, target)
+			default:
+				d.openPicker("SHELL "+row.Name, "", func(query string) data.Table {
+					return containerTable(running, query, def)
+				}, func(r data.Row) {
+					target.Container = r.Name
+					d.runShell(session, target)
+				})
+			}
+		})
+	}()
+}
+
+// runShell suspende a TUI e liga o terminal a um shell no container (bash
+// ou sh). Ao sair do shell o painel volta como estava. Roda na goroutine da
+// UI.
+func (d *Dashboard) runShell(session *kube.Session, t data.LogTarget) {
+	ref := fmt.Sprintf("%s/%s (%s)", displayNS(t.Namespace), t.Pod, t.Container)
+	var shell string
+	var err error
+	d.app.Suspend(func() {
+		var tty *terminal
+		if tty, err = openTerminal(); err != nil {
+			return
+		}
+		defer tty.close()
+		fmt.Fprintf(tty, "ktwins: shell em %s, contexto %s. exit ou Ctrl+D volta ao painel.\r\n", ref, session.Name)
+		shell, err = data.ExecShell(context.Background(), session.Config, session.Clientset, t,
+			data.ExecStreams{Stdin: tty, Stdout: tty, Sizes: tty.Sizes()})
+	})
+	switch {
+	case err != nil && shell == "":
+		go d.showInfo(fmt.Sprintf("Shell em %s: %v (a imagem tem %s?)", ref, err, strings.Join(data.Shells, " ou ")))
+	case err != nil:
+		go d.showInfo(fmt.Sprintf("Shell %s em %s: %v", shell, ref, err))
+	}
+}
+
+// sizeQueue repassa ao exec o tamanho do terminal; guarda só o mais recente.
+type sizeQueue struct {
+	ch   chan remotecommand.TerminalSize
+	done chan struct{}
+}
+
+func newSizeQueue() *sizeQueue {
+	return &sizeQueue{ch: make(chan remotecommand.TerminalSize, 1), done: make(chan struct{})}
+}
+
+func (q *sizeQueue) push(width, height int) {
+	size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+	select {
+	case <-q.ch: // descarta o anterior ainda não lido
+	default:
+	}
+	select {
+	case q.ch <- size:
+	default:
+	}
+}
+
+// Next bloqueia até o próximo tamanho; nil encerra o repasse.
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.ch:
+		return &size
+	case <-q.done:
+		return nil
+	}
+}
+
+func (q *sizeQueue) close() {
+	close(q.done)
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"ktwins/internal/theme"
+)
+
+// historyPanel é o painel de rollout history: as revisões em cima e, embaixo,
+// o diff do pod template da revisão selecionada contra a base (a marcada com
+// Space, ou a atual).
+type historyPanel struct {
+	target actionTarget
+	revs   []data.Revision
+	list   *listView
+	diff   *tview.TextView
+	base   int64 // revisão marcada para comparar; 0 = a atual
+}
+
+func (d *Dashboard) openHistory(t actionTarget) {
+	go func() {
+		revs, err := data.RolloutHistory(context.Background(), t.session.Clientset, t.kind, t.namespace, t.name)
+		_ = d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				go d.showInfo(fmt.Sprintf("Erro em %s: %v", t.ref(), err))
+				return
+			}
+			if len(revs) == 0 {
+				go d.showInfo("Sem revisões para " + t.ref())
+				return
+			}
+			d.showHistory(&historyPanel{target: t, revs: revs})
+		})
+	}()
+}
+
+// showHistory abre o painel, a não ser que outro overlay tenha aberto
+// enquanto RolloutHistory respondia.
+func (d *Dashboard) showHistory(h *historyPanel) {
+	if d.overlayOpen() {
+		return
+	}
+	restore := d.app.GetFocus()
+	h.list = newListView("", false)
+	h.list.SetBorder(false)
+	h.diff = newTextArea("")
+	h.diff.SetBorder(false)
+	h.list.onSelect = func(*data.Row) { h.renderDiff() }
+
+	box := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(h.list, min(len(h.revs)+1, 12), 0, true).
+		AddItem(h.diff, 0, 1, false)
+	box.SetBorder(true).SetBorderColor(tcell.ColorWheat).
+		SetTitle(fmt.Sprintf(" HISTORY %s  [Space] marca a base do diff  [u] rollback  (Esc fecha) ", h.target.ref()))
+
+	closeHistory := func() {
+		d.pages.RemovePage("history")
+		d.panelOpen = false
+		if restore != nil {
+			d.app.SetFocus(restore)
+		}
+	}
+	h.list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEscape:
+			closeHistory()
+			return nil
+		case tcell.KeyUp:
+			h.list.move(-1)
+			return nil
+		case tcell.KeyDown:
+			h.list.move(1)
+			return nil
+		case tcell.KeyPgUp, tcell.KeyPgDn:
+			// o diff rola; a lista é curta
+			h.diff.InputHandler()(ev, nil)
+			return nil
+		case tcell.KeyRune:
+			switch ev.Rune() {
+			case ' ':
+				h.toggleBase()
+				return nil
+			case 'u':
+				if rev := h.selected(); rev != nil {
+					closeHistory()
+					d.confirmRollback(h.target, *rev)
+				}
+				return nil
+			}
+		}
+		return ev
+	})
+
+	h.refreshList()
+	// o painel é modal: handleInput deixa as teclas com ele
+	d.panelOpen = true
+	d.pages.AddPage("history", box, true, true)
+	d.app.SetFocus(h.list)
+}
+
+func (h *historyPanel) refreshList() {
+	selected := ""
+	if rev := h.selected(); rev != nil {
+		selected = rev.Name
+	}
+	h.list.SetTables([]data.Table{data.HistoryTable(h.revs, h.base)})
+	h.list.startBrowse()
+	for row := range h.list.GetRowCount() {
+		if r := h.list.rowAt(row); r != nil && r.UID == selected {
+			h.list.selectRow(row)
+		}
+	}
+	h.renderDiff()
+}
+
+func (h *historyPanel) selected() *data.Revision {
+	r := h.list.selectedRow()
+	if r == nil {
+		return nil
+	}
+	for i := range h.revs {
+		if h.revs[i].Name == r.UID {
+			return &h.revs[i]
+		}
+	}
+	return nil
+}
+
+// baseRevision é a marcada com Space ou, sem marca, a atual.
+func (h *historyPanel) baseRevision() *data.Revision {
+	for i := range h.revs {
+		if (h.base != 0 && h.revs[i].Number == h.base) || (h.base == 0 && h.revs[i].Current) {
+			return &h.revs[i]
+		}
+	}
+	return nil
+}
+
+func (h *historyPanel) toggleBase() {
+	rev := h.selected()
+	if rev == nil {
+		return
+	}
+	if h.base == rev.Number {
+		h.base = 0
+	} else {
+		h.base = rev.Number
+	}
+	h.refreshList()
+}
+
+func (h *historyPanel) renderDiff() {
+	rev, base := h.selected(), h.baseRevision()
+	if rev == nil || base == nil {
+		h.diff.SetText("")
+		return
+	}
+	if rev.Number == base.Number {
+		h.diff.SetText(fmt.Sprintf("%sRevisão %d é a base do diff; selecione outra para comparar.%s", theme.Yellow, rev.Number, theme.Reset))
+		return
+	}
+	a, errA := base.TemplateManifest()
+	b, errB := rev.TemplateManifest()
+	if errA != nil || errB != nil {
+		h.diff.SetText(tview.Escape(fmt.Sprint(errA, errB)))
+		return
+	}
+	text, changes := formatManifestDiff(a, b, "revisão "+strconv.FormatInt(base.Number, 10)+" (base)", "revisão "+strconv.FormatInt(rev.Number, 10))
+	if changes == 0 {
+		text = fmt.Sprintf("%sRevisões %d e %d têm o mesmo pod template.%s", theme.Green, base.Number, rev.Number, theme.Reset)
+	}
+	h.diff.SetText(text)
+	h.diff.ScrollToBeginning()
+}
+
+// confirmRollback volta o workload à revisão, depois da confirmação digitada.
+func (d *Dashboard) confirmRollback(t actionTarget, rev data.Revision) {
+	if rev.Current {
+		go d.showInfo(fmt.Sprintf("%s já está na revisão %d", t.ref(), rev.Number))
+		return
+	}
+	c := t.session.Clientset
+	action := fmt.Sprintf("rollout undo %s --to-revision=%d (%s)", t.ref(), rev.Number, rev.Name)
+	d.confirmAction(t, action, fmt.Sprintf("%s voltando à revisão %d", t.ref(), rev.Number), func(ctx context.Context) error {
+		return data.Rollback(ctx, c, t.kind, t.namespace, t.name, rev)
+	})
+}
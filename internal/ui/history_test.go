@@ -0,0 +1,18 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+)
+
+// TestShowHistoryBehindOverlay garante que o history que chega depois de um
+// picker aberto não se empilha sobre ele nem mexe no estado do picker.
+func TestShowHistoryBehindOverlay(t *testing.T) {
+	d := &Dashboard{pages: tview.NewPages(), pickerOpen: true}
+	d.showHistory(&historyPanel{revs: []data.Revision{{}}})
+	if d.pages.HasPage("history") || d.panelOpen || !d.pickerOpen {
+		t.Errorf("history opened over the picker (panelOpen=%v, pickerOpen=%v)", d.panelOpen, d.pickerOpen)
+	}
+}
@@ -0,0 +1,479 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+)
+
+// listView é um box de listagem sobre tview.Table: cabeçalho fixo, colunas
+// alinhadas, rolagem horizontal e seleção presa ao UID do objeto.
+// As linhas ficam em listContent e só a janela visível vira célula do tview,
+// então listas com milhares de objetos não pesam no redraw.
+type listView struct {
+	*tview.Table
+
+	content  *listContent
+	grouped  bool        // vários kinds no mesmo box (coluna KIND)
+	color    tcell.Color // cor padrão das linhas
+	spans    []headerSpan
+	shown    int // span cujo cabeçalho está na linha fixa
+	hasData  bool
+	browsing bool
+	selected string // chave (UID) do objeto selecionado
+
+	title  string // título sem o indicador de janela
+	window string // "showing a-b of n" do último Draw
+
+	tables     []data.Table // último conteúdo, para reordenar sem esperar o refresh
+	sortColumn string       // nome da coluna; "" = ordem do backend (namespace/nome)
+	sortDesc   bool
+
+	filter  data.Filter
+	matched int // linhas que passaram no filtro
+	total   int // linhas antes do filtro
+
+	mirror   *listView       // outro lado do modo twins: seleção e rolagem andam juntas
+	onSelect func(*data.Row) // troca de seleção durante a navegação (painéis com detalhe)
+}
+
+// headerSpan marca onde começa cada grupo e qual cabeçalho vale para ele.
+type headerSpan struct {
+	start  int
+	header []string
+}
+
+// listLine é uma linha já montada; a célula do tview só é criada no GetCell.
+type listLine struct {
+	cells  []string
+	color  tcell.Color
+	header bool
+	pad    bool      // colunas alinhadas (mensagens ocupam só a primeira célula)
+	ref    *data.Row // nil = linha não selecionável
+	index  int       // posição entre as linhas selecionáveis, para o indicador
+}
+
+// listContent implementa tview.TableContent sobre as linhas do listView.
+type listContent struct {
+	tview.TableContentReadOnly
+	lines   []listLine
+	columns int
+	rows    int // linhas selecionáveis
+}
+
+func (c *listContent) GetCell(row, column int) *tview.TableCell {
+	if row < 0 || row >= len(c.lines) {
+		return nil
+	}
+	l := &c.lines[row]
+	if column >= len(l.cells) {
+		return nil
+	}
+	text := tview.Escape(l.cells[column])
+	if l.pad {
+		text += cellPadding
+	}
+	cell := tview.NewTableCell(text).SetTextColor(l.color)
+	if l.header {
+		cell.SetTextColor(tcell.ColorSkyblue).SetAttributes(tcell.AttrBold)
+	}
+	return cell.SetSelectable(l.ref != nil)
+}
+
+func (c *listContent) GetRowCount() int {
+	return len(c.lines)
+}
+
+func (c *listContent) GetColumnCount() int {
+	return c.columns
+}
+
+func (c *listContent) add(l listLine) {
+	if l.ref != nil {
+		l.index = c.rows
+		c.rows++
+	}
+	if len(l.cells) > c.columns {
+		c.columns = len(l.cells)
+	}
+	c.lines = append(c.lines, l)
+}
+
+const cellPadding = "  " // + separador do tview = 3 espaços, como o tabwriter do kubectl
+
+func newListView(title string, grouped bool) *listView {
+	content := &listContent{}
+	t := tview.NewTable().
+		SetContent(content).
+		SetFixed(1, 0).
+		SetSelectable(false, false).
+		SetSelectedStyle(tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow))
+	t.SetBorder(true).SetTitle(title)
+	v := &listView{Table: t, content: content, grouped: grouped, color: tcell.ColorWhite, title: title}
+	// teclas da própria Table (Home/End, PgUp/PgDn) e cliques também contam como seleção
+	t.SetSelectionChangedFunc(func(row, _ int) {
+		if r := v.rowAt(row); r != nil {
+			v.selected = rowKey(r)
+			if v.onSelect != nil && v.browsing {
+				v.onSelect(r)
+			}
+		}
+		if v.mirror != nil && v.browsing {
+			v.mirror.follow(row)
+		}
+	})
+	return v
+}
+
+// follow seleciona a mesma linha do box espelhado; os lados do modo twins
+// têm as mesmas linhas na mesma ordem.
+func (v *listView) follow(row int) {
+	if cur, _ := v.GetSelection(); v.browsing && cur == row {
+		return
+	}
+	if v.rowAt(row) == nil {
+		return
+	}
+	v.browsing = true
+	v.SetSelectable(true, false)
+	v.selectRow(row)
+}
+
+// Draw redesenha quando a rolagem muda o grupo no topo ou a janela visível,
+// para o cabeçalho fixo e o indicador do título acompanharem.
+func (v *listView) Draw(screen tcell.Screen) {
+	if v.mirror != nil && !v.browsing && v.mirror.HasFocus() {
+		v.SetOffset(v.mirror.GetOffset())
+	}
+	v.Table.Draw(screen)
+	changed := v.syncHeader()
+	if window := v.windowLabel(); window != v.window {
+		v.window = window
+		v.Table.SetTitle(v.title + window)
+		changed = true
+	}
+	if changed {
+		v.Table.Draw(screen)
+	}
+}
+
+// SetTitle guarda o título base; o indicador de janela é acrescentado no Draw.
+func (v *listView) SetTitle(title string) *tview.Box {
+	v.title = title
+	return v.Table.SetTitle(title + v.window)
+}
+
+// windowLabel descreve quais linhas estão na tela quando nem todas cabem.
+func (v *listView) windowLabel() string {
+	total := v.content.rows
+	if total == 0 {
+		return ""
+	}
+	offset, _ := v.GetOffset()
+	_, _, _, height := v.GetInnerRect()
+	first, last := -1, -1
+	for row := offset + 1; row < len(v.content.lines) && row < offset+height; row++ {
+		if l := &v.content.lines[row]; l.ref != nil {
+			if first == -1 {
+				first = l.index
+			}
+			last = l.index
+		}
+	}
+	if first == -1 || (first == 0 && last == total-1) {
+		return ""
+	}
+	return tview.Escape(fmt.Sprintf(" [showing %d-%d of %d]", first+1, last+1, total))
+}
+
+func (v *listView) reset() {
+	v.content.lines = nil
+	v.content.columns = 0
+	v.content.rows = 0
+	v.spans = nil
+	v.shown = 0
+	v.hasData = false
+}
+
+func (v *listView) setText(msg string) {
+	v.reset()
+	v.content.add(listLine{cells: []string{msg}, color: v.color})
+}
+
+func (v *listView) SetTables(tables []data.Table) {
+	v.tables = tables
+	v.reset()
+	v.matched, v.total = 0, 0
+
+	v.content.add(listLine{header: true}) // linha 0 é o cabeçalho fixo
+	for _, t := range tables {
+		if t.Err != nil {
+			msg := t.Err.Error()
+			if v.grouped {
+				msg = strings.ToUpper(t.Kind) + ": " + msg
+			}
+			v.content.add(listLine{cells: []string{msg}, color: tcell.ColorRed})
+			v.hasData = true
+			continue
+		}
+		v.total += len(t.Rows)
+		data.FilterRows(&t, v.filter)
+		v.matched += len(t.Rows)
+		if len(t.Rows) == 0 {
+			continue
+		}
+		if v.sortColumn != "" {
+			// sem filtro t.Rows ainda é o slice de v.tables; ordenar no lugar
+			// perderia a ordem padrão que cycleSort restaura.
+			t.Rows = slices.Clone(t.Rows)
+			data.SortRows(&t, v.sortColumn, v.sortDesc)
+		}
+		header := t.Header
+		if v.grouped {
+			header = append([]string{"KIND"}, header...)
+		}
+		start := len(v.content.lines)
+		if len(v.spans) > 0 {
+			v.content.add(v.headerLine(header))
+		} else {
+			start = 1
+		}
+		v.spans = append(v.spans, headerSpan{start: start, header: header})
+		for i := range t.Rows {
+			r := &t.Rows[i]
+			cells := r.Columns
+			if v.grouped {
+				cells = append([]string{r.Kind}, cells...)
+			}
+			v.content.add(listLine{cells: cells, color: v.rowColor(r), pad: true, ref: r})
+		}
+		v.hasData = true
+	}
+	switch {
+	case len(v.spans) > 0:
+		v.content.lines[0] = v.headerLine(v.spans[0].header)
+	case v.total > 0:
+		// tudo filtrado: o box continua visível para o filtro poder ser ajustado
+		v.content.lines[0] = listLine{cells: []string{fmt.Sprintf("sem resultados para /%s", v.filter)}, color: tcell.ColorGray}
+		v.hasData = true
+	}
+	v.restoreSelection()
+}
+
+func (v *listView) rowColor(r *data.Row) tcell.Color {
+	switch r.Health {
+	case data.HealthError:
+		return tcell.ColorRed
+	case data.HealthWarning:
+		return tcell.ColorYellow
+	}
+	return v.color
+}
+
+func (v *listView) headerLine(header []string) listLine {
+	cells := make([]string, len(header))
+	for c, h := range header {
+		if h == v.sortColumn && h != "" {
+			h += v.sortArrow()
+		}
+		cells[c] = h
+	}
+	return listLine{cells: cells, header: true, pad: true}
+}
+
+// syncHeader põe na linha fixa o cabeçalho do grupo visível no topo.
+func (v *listView) syncHeader() bool {
+	if len(v.spans) < 2 {
+		return false
+	}
+	offset, _ := v.GetOffset()
+	top := offset + 1
+	cur := 0
+	for i, s := range v.spans {
+		if s.start <= top {
+			cur = i
+		}
+	}
+	if cur == v.shown {
+		return false
+	}
+	v.shown = cur
+	v.content.lines[0] = v.headerLine(v.spans[cur].header)
+	return true
+}
+
+func (v *listView) hasContent() bool {
+	return v.hasData
+}
+
+func rowKey(r *data.Row) string {
+	if r.UID != "" {
+		return r.UID
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+func (v *listView) rowAt(row int) *data.Row {
+	if row < 0 || row >= len(v.content.lines) {
+		return nil
+	}
+	return v.content.lines[row].ref
+}
+
+func (v *listView) firstSelectable() int {
+	for row := range v.content.lines {
+		if v.rowAt(row) != nil {
+			return row
+		}
+	}
+	return -1
+}
+
+func (v *listView) startBrowse() bool {
+	row := v.firstSelectable()
+	if row == -1 {
+		return false
+	}
+	v.browsing = true
+	v.SetSelectable(true, false)
+	v.selectRow(row)
+	return true
+}
+
+func (v *listView) stopBrowse() {
+	v.browsing = false
+	v.selected = ""
+	v.SetSelectable(false, false)
+	if v.mirror != nil && v.mirror.browsing {
+		v.mirror.stopBrowse()
+	}
+}
+
+func (v *listView) selectRow(row int) {
+	v.Select(row, 0)
+}
+
+// move anda delta linhas pulando cabeçalhos; não dá a volta no fim da lista.
+func (v *listView) move(delta int) {
+	cur, _ := v.GetSelection()
+	for row := cur + delta; row >= 0 && row < v.GetRowCount(); row += delta {
+		if v.rowAt(row) != nil {
+			v.selectRow(row)
+			return
+		}
+	}
+}
+
+// restoreSelection reencontra o objeto pelo UID depois de um refresh; se ele
+// sumiu, fica na linha selecionável mais próxima da posição anterior.
+func (v *listView) restoreSelection() {
+	if !v.browsing {
+		return
+	}
+	for row, l := range v.content.lines {
+		if l.ref != nil && rowKey(l.ref) == v.selected {
+			v.Select(row, 0)
+			return
+		}
+	}
+	cur, _ := v.GetSelection()
+	for dist := 0; dist < v.GetRowCount(); dist++ {
+		for _, row := range []int{cur - dist, cur + dist} {
+			if v.rowAt(row) != nil {
+				v.selectRow(row)
+				return
+			}
+		}
+	}
+	v.stopBrowse()
+}
+
+func (v *listView) selectedRow() *data.Row {
+	if !v.browsing {
+		return nil
+	}
+	row, _ := v.GetSelection()
+	return v.rowAt(row)
+}
+
+func (v *listView) sortArrow() string {
+	if v.sortDesc {
+		return "▼"
+	}
+	return "▲"
+}
+
+// sortColumns lista as colunas de todos os grupos do box, na ordem em que aparecem.
+func (v *listView) sortColumns() []string {
+	seen := map[string]bool{}
+	var cols []string
+	for _, t := range v.tables {
+		for _, h := range t.Header {
+			if !seen[h] {
+				seen[h] = true
+				cols = append(cols, h)
+			}
+		}
+	}
+	return cols
+}
+
+// cycleSort passa para a próxima coluna (ascendente); depois da última volta à ordem padrão.
+func (v *listView) cycleSort() {
+	cols := v.sortColumns()
+	if len(cols) == 0 {
+		return
+	}
+	next := 0
+	if v.sortColumn != "" {
+		next = len(cols)
+		for i, c := range cols {
+			if c == v.sortColumn {
+				next = i + 1
+				break
+			}
+		}
+	}
+	v.sortDesc = false
+	if next >= len(cols) {
+		v.sortColumn = ""
+	} else {
+		v.sortColumn = cols[next]
+	}
+	v.SetTables(v.tables)
+}
+
+func (v *listView) reverseSort() {
+	if v.sortColumn == "" {
+		cols := v.sortColumns()
+		if len(cols) == 0 {
+			return
+		}
+		v.sortColumn = cols[0]
+	}
+	v.sortDesc = !v.sortDesc
+	v.SetTables(v.tables)
+}
+
+func (v *listView) sortLabel() string {
+	if v.sortColumn == "" {
+		return ""
+	}
+	return "sort " + v.sortColumn + v.sortArrow()
+}
+
+func (v *listView) setFilter(f data.Filter) {
+	v.filter = f
+	v.SetTables(v.tables)
+}
+
+func (v *listView) filterLabel() string {
+	if v.filter.Empty() {
+		return ""
+	}
+	return fmt.Sprintf("/%s %d/%d", v.filter, v.matched, v.total)
+}
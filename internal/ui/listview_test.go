@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"ktwins/internal/data"
+)
+
+func shownNames(v *listView) []string {
+	var names []string
+	for _, l := range v.content.lines {
+		if l.ref != nil {
+			names = append(names, l.ref.Name)
+		}
+	}
+	return names
+}
+
+func TestCycleSortRestoresDefaultOrder(t *testing.T) {
+	v := newListView("PODS", false)
+	v.SetTables([]data.Table{{
+		Kind:   "pods",
+		Header: []string{"NAME", "STATUS"},
+		Rows: []data.Row{
+			{Name: "a", UID: "1", Columns: []string{"a", "Running"}},
+			{Name: "b", UID: "2", Columns: []string{"b", "Failed"}},
+			{Name: "c", UID: "3", Columns: []string{"c", "Pending"}},
+		},
+	}})
+
+	v.cycleSort() // NAME
+	v.cycleSort() // STATUS
+	if got := shownNames(v); !slices.Equal(got, []string{"b", "c", "a"}) {
+		t.Fatalf("sorted by STATUS: got %v", got)
+	}
+	v.cycleSort() // ordem padrão
+	if v.sortColumn != "" {
+		t.Fatalf("sortColumn = %q, want default order", v.sortColumn)
+	}
+	if got := shownNames(v); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("default order: got %v", got)
+	}
+}
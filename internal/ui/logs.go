@@ -0,0 +1,807 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"ktwins/internal/data"
+)
+
+const (
+	logBufferLines = 5000                   // ring buffer do modal; as mais antigas saem primeiro
+	logTail        = 200                    // linhas iniciais, como o antigo --tail=200
+	logRedraw      = 250 * time.Millisecond // agrupa linhas que chegam em rajada
+	logMaxBackoff  = 10 * time.Second
+	logDiscovery   = 3 * time.Second // nova consulta dos pods de um workload
+	logStampLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// logRange é o recorte inicial do log, escolhido com [s]: as últimas tail
+// linhas, a janela since até agora, um início fixo ou (tudo zerado) o log
+// inteiro da instância atual, isto é, desde o último restart.
+type logRange struct {
+	label string
+	tail  int64
+	since time.Duration // relativo ao início de cada stream
+	start time.Time
+}
+
+var logRanges = []logRange{
+	{label: fmt.Sprintf("tail %d", logTail), tail: logTail},
+	{label: "5m", since: 5 * time.Minute},
+	{label: "1h", since: time.Hour},
+	{label: "since restart"},
+}
+
+func (r logRange) options(previous bool) data.LogOptions {
+	o := data.LogOptions{Tail: r.tail, Since: r.start, Previous: previous}
+	if r.since > 0 {
+		o.Since = time.Now().Add(-r.since)
+	}
+	return o
+}
+
+// logRing guarda as últimas linhas recebidas, em ordem de chegada (add) ou
+// de timestamp (insert).
+type logRing struct {
+	lines []data.LogLine
+	start int
+	size  int
+}
+
+func newLogRing(capacity int) *logRing {
+	return &logRing{lines: make([]data.LogLine, capacity)}
+}
+
+func (r *logRing) add(l data.LogLine) {
+	end := (r.start + r.size) % len(r.lines)
+	r.lines[end] = l
+	if r.size < len(r.lines) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.lines)
+	}
+}
+
+// insert põe l depois da última linha com timestamp até o dele. Cada
+// stream chega em ordem, então a busca a partir do fim quase sempre para na
+// primeira comparação; só o tail inicial de um pod novo anda mais. Com o
+// ring cheio sai a mais antiga, ou a própria l se for anterior a todas.
+func (r *logRing) insert(l data.LogLine) {
+	if l.Time.IsZero() {
+		r.add(l)
+		return
+	}
+	i := r.size
+	for i > 0 && r.at(i-1).Time.After(l.Time) {
+		i--
+	}
+	if r.size == len(r.lines) {
+		if i == 0 {
+			return
+		}
+		r.start = (r.start + 1) % len(r.lines)
+		r.size--
+		i--
+	}
+	for j := r.size; j > i; j-- {
+		r.lines[(r.start+j)%len(r.lines)] = r.at(j - 1)
+	}
+	r.lines[(r.start+i)%len(r.lines)] = l
+	r.size++
+}
+
+func (r *logRing) at(i int) data.LogLine {
+	return r.lines[(r.start+i)%len(r.lines)]
+}
+
+func (r *logRing) all() []data.LogLine {
+	out := make([]data.LogLine, r.size)
+	for i := range out {
+		out[i] = r.lines[(r.start+i)%len(r.lines)]
+	}
+	return out
+}
+
+// logStream alimenta o modal de logs em follow: cada fonte (pod/container)
+// roda numa goroutine, as linhas vão para o ring e o TextView é redesenhado
+// a cada logRedraw. Com várias fontes as linhas ganham prefixo colorido e
+// são intercaladas por timestamp. A rolagem automática é a do próprio
+// TextView (rolar para cima desliga, End/G religa); follow só espelha esse
+// estado no título.
+type logStream struct {
+	app    *tview.Application
+	view   *tview.TextView
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	name     string // pod/container ou kind/nome, para o título
+	multi    bool   // várias fontes: prefixo e ordem por timestamp
+	ring     *logRing
+	dirty    bool // título ou texto mudaram
+	grown    bool // chegaram linhas desde o último render
+	paused   bool
+	pending  int               // linhas recebidas durante a pausa
+	status   map[string]string // por fonte ("" = o stream todo): reconexão ou erro
+	colors   map[string]string // cor do prefixo de cada fonte
+	sources  int               // streams ativos (modo multi)
+	podCount int
+	follow   bool
+
+	search  *regexp.Regexp // busca do modal; nil = sem busca
+	query   string
+	matches int
+	current int  // ocorrência destacada (região "m<n>")
+	jump    bool // rolar até a ocorrência atual no próximo redraw
+	raw     bool // linhas JSON como vieram, sem alinhar campos
+	stamps  bool // prefixo com o timestamp do servidor
+	force   bool // redesenhar mesmo em pausa (busca, raw, timestamps)
+}
+
+// logColors são as cores dos prefixos, atribuídas na ordem em que as fontes aparecem.
+var logColors = []string{"aqua", "fuchsia", "lime", "orange", "violet", "gold", "skyblue", "pink", "lightgreen", "tomato"}
+
+func newLogStream(app *tview.Application, view *tview.TextView, name string, multi bool) *logStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &logStream{
+		app: app, view: view, ctx: ctx, cancel: cancel,
+		name: name, multi: multi, ring: newLogRing(logBufferLines),
+		status: map[string]string{}, colors: map[string]string{},
+		follow: true, dirty: true,
+	}
+}
+
+// run segue um único container até stop.
+func (s *logStream) run(c kubernetes.Interface, t data.LogTarget, o data.LogOptions) {
+	go s.redrawLoop(s.ctx)
+	s.followTarget(s.ctx, c, t, o, "")
+}
+
+// runSelector segue todos os containers dos pods que casam com selector,
+// consultando a lista de pods a cada logDiscovery para pegar pods novos e
+// largar os que sumiram.
+func (s *logStream) runSelector(c kubernetes.Interface, namespace, selector string, o data.LogOptions) {
+	ctx := s.ctx
+	go s.redrawLoop(ctx)
+	active := map[string]context.CancelFunc{}
+	for {
+		targets, pods, err := data.SelectorTargets(ctx, c, namespace, selector)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			s.setStatus("", "✗ erro ao listar pods: "+err.Error())
+		default:
+			seen := map[string]bool{}
+			for _, t := range targets {
+				source := t.Pod + "/" + t.Container
+				seen[source] = true
+				if _, ok := active[source]; !ok {
+					sctx, cancel := context.WithCancel(ctx)
+					active[source] = cancel
+					go s.followTarget(sctx, c, t, o, source)
+				}
+			}
+			for source, cancel := range active {
+				if !seen[source] {
+					cancel()
+					delete(active, source)
+					s.setStatus(source, "")
+				}
+			}
+			s.setPods(pods, len(active))
+			s.setStatus("", "")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(logDiscovery):
+		}
+	}
+}
+
+// followTarget segue um container até ctx acabar; quedas do stream viram
+// reconexões com backoff, retomando do timestamp da última linha recebida.
+// A instância anterior (--previous) já terminou: lida uma vez, sem reconexão.
+func (s *logStream) followTarget(ctx context.Context, c kubernetes.Interface, t data.LogTarget, o data.LogOptions, source string) {
+	var last time.Time
+	attempt := 0
+	for {
+		err := data.FollowLogs(ctx, c, t, o, last, func(l data.LogLine) {
+			// SinceTime tem precisão de segundos: a reconexão repete parte do último segundo
+			if !last.IsZero() && !l.Time.After(last) {
+				return
+			}
+			if !l.Time.IsZero() {
+				last = l.Time
+			}
+			attempt = 0
+			l.Source = source
+			s.append(l)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if o.Previous {
+			if err != nil {
+				s.setStatus(source, "✗ erro: "+err.Error())
+			} else {
+				s.setStatus(source, "✓ instância anterior")
+			}
+			return
+		}
+		if apierrors.IsNotFound(err) {
+			if source == "" {
+				s.setStatus(source, "✗ encerrado: "+err.Error())
+			}
+			return // no modo multi, a descoberta larga o pod que sumiu
+		}
+		attempt++
+		msg := fmt.Sprintf("⟳ reconectando (%d)", attempt)
+		if err != nil {
+			msg += ": " + err.Error()
+		}
+		s.setStatus(source, msg)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(min(time.Duration(attempt)*time.Second, logMaxBackoff)):
+		}
+	}
+}
+
+func (s *logStream) stop() {
+	s.cancel()
+}
+
+func (s *logStream) append(l data.LogLine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.multi {
+		s.ring.insert(l)
+	} else {
+		s.ring.add(l)
+	}
+	s.grown = true
+	delete(s.status, l.Source)
+	if s.paused {
+		s.pending++
+	}
+	if _, ok := s.colors[l.Source]; !ok {
+		s.colors[l.Source] = logColors[len(s.colors)%len(logColors)]
+	}
+	s.dirty = true
+}
+
+func (s *logStream) setStatus(source, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == "" {
+		delete(s.status, source)
+	} else {
+		s.status[source] = status
+	}
+	s.dirty = true
+}
+
+func (s *logStream) setPods(pods, sources int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.podCount != pods || s.sources != sources {
+		s.podCount, s.sources = pods, sources
+		s.dirty = true
+	}
+}
+
+// togglePause congela o modal; as linhas continuam chegando ao ring.
+func (s *logStream) togglePause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = !s.paused
+	s.pending = 0
+	s.dirty = true
+}
+
+func (s *logStream) setFollow(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.follow != on {
+		s.follow = on
+		s.dirty = true
+	}
+}
+
+func (s *logStream) redrawLoop(ctx context.Context) {
+	tick := time.NewTicker(logRedraw)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+		s.mu.Lock()
+		if !s.dirty {
+			s.mu.Unlock()
+			continue
+		}
+		s.dirty = false
+		var text string
+		// status, pods e follow só mudam o título
+		render := s.grown && !s.paused || s.force
+		if render {
+			text = s.render()
+			s.grown = false
+		}
+		s.force = false
+		jump, highlight := s.jump, ""
+		if s.search != nil && s.matches > 0 {
+			highlight = fmt.Sprintf("m%d", s.current)
+		}
+		s.jump = false
+		title := s.title()
+		s.mu.Unlock()
+
+		_ = s.app.QueueUpdateDraw(func() {
+			if ctx.Err() != nil {
+				return // modal já fechado
+			}
+			s.view.SetTitle(title)
+			if render {
+				s.view.SetText(text)
+			}
+			if jump {
+				if highlight == "" {
+					s.view.Highlight()
+				} else {
+					s.view.Highlight(highlight).ScrollToHighlight()
+				}
+			}
+		})
+	}
+}
+
+// title resume o estado do stream; chamado com mu travado.
+func (s *logStream) title() string {
+	state := "● live"
+	switch {
+	case s.status[""] != "":
+		state = s.status[""]
+	case s.multi && len(s.status) > 0:
+		state = fmt.Sprintf("⟳ %d de %d streams reconectando", len(s.status), s.sources)
+	case s.paused:
+		state = fmt.Sprintf("⏸ paused +%d", s.pending)
+	case !s.follow:
+		state = "● live, auto-scroll off"
+	}
+	name, keys := s.name, "[p]ause [End] follow [/] search [s]ince [t]imestamps [r]aw [c]ontainer [P]revious [w]/[W] save"
+	if s.multi {
+		name += fmt.Sprintf(" (%d pods)", s.podCount)
+		keys = "[p]ause [End] follow [/] search [s]ince [t]imestamps [r]aw [w] save"
+	}
+	title := fmt.Sprintf("LOGS %s [%s]", name, state)
+	if s.search != nil {
+		title += fmt.Sprintf(" [/%s %d/%d n/N]", s.query, min(s.current+1, s.matches), s.matches)
+	}
+	return tview.Escape(title + " " + keys + " (Esc fecha)")
+}
+
+// setSearch busca query (regex sem diferenciar maiúsculas; texto literal se
+// não compilar) e vai para a primeira ocorrência; vazio desliga a busca.
+func (s *logStream) setSearch(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.query = strings.TrimSpace(query)
+	s.search = nil
+	if s.query != "" {
+		re, err := regexp.Compile("(?i)" + s.query)
+		if err != nil {
+			re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(s.query))
+		}
+		s.search = re
+	}
+	s.current = 0
+	s.jump, s.force, s.dirty = true, true, true
+}
+
+// nextMatch anda delta ocorrências, dando a volta nas pontas.
+func (s *logStream) nextMatch(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.search == nil || s.matches == 0 {
+		return
+	}
+	s.current = (s.current + delta + s.matches) % s.matches
+	s.jump, s.force, s.dirty = true, true, true
+}
+
+func (s *logStream) setTimestamps(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stamps = on
+	s.force, s.dirty = true, true
+}
+
+func (s *logStream) toggleRaw() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.raw = !s.raw
+	s.force, s.dirty = true, true
+}
+
+// render monta o texto do modal: cor por nível, linhas JSON com time,
+// level e msg alinhados (ou cruas, com raw) e as ocorrências da busca como
+// regiões "m<n>". Chamado com mu travado.
+func (s *logStream) render() string {
+	lines := s.ring.all()
+	entries := make([]*data.LogEntry, len(lines))
+	timeWidth := 0
+	if !s.raw {
+		for i, l := range lines {
+			if e, ok := data.ParseJSONLog(l.Text); ok {
+				entries[i] = &e
+				timeWidth = max(timeWidth, len(e.Time))
+			}
+		}
+	}
+
+	var b strings.Builder
+	match := 0
+	for i, l := range lines {
+		if s.multi {
+			fmt.Fprintf(&b, "[%s]%s[-] ", s.colors[l.Source], tview.Escape(l.Source))
+		}
+		if s.stamps && !l.Time.IsZero() {
+			b.WriteString("[gray]" + l.Time.UTC().Format(logStampLayout) + "[-] ")
+		}
+		text, level := l.Text, data.LevelNone
+		if e := entries[i]; e != nil {
+			text = fmt.Sprintf("%-*s  %-5s  %s", timeWidth, e.Time, strings.ToUpper(e.Label), e.Msg)
+			if len(e.Extra) > 0 {
+				text += "  " + strings.Join(e.Extra, " ")
+			}
+			level = e.Level
+		} else {
+			level = data.DetectLevel(l.Text)
+		}
+		color := levelColors[level]
+		if color != "" {
+			b.WriteString("[" + color + "]")
+		}
+		b.WriteString(markMatches(text, s.search, &match))
+		if color != "" {
+			b.WriteString("[-]")
+		}
+		b.WriteByte('\n')
+	}
+	s.matches = match
+	if s.current >= match {
+		s.current = max(match-1, 0)
+	}
+	return b.String()
+}
+
+var levelColors = map[data.LogLevel]string{
+	data.LevelError: "red",
+	data.LevelWarn:  "yellow",
+	data.LevelInfo:  "green",
+	data.LevelDebug: "gray",
+}
+
+// markMatches escapa text e envolve cada ocorrência numa região numerada a
+// partir de *next, com fundo amarelo (o destaque da atual é o da região).
+func markMatches(text string, re *regexp.Regexp, next *int) string {
+	if re == nil {
+		return tview.Escape(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		b.WriteString(tview.Escape(text[last:loc[0]]))
+		fmt.Fprintf(&b, `["m%d"][:yellow]%s[:-][""]`, *next, tview.Escape(text[loc[0]:loc[1]]))
+		*next++
+		last = loc[1]
+	}
+	b.WriteString(tview.Escape(text[last:]))
+	return b.String()
+}
+
+// logSource é o que o modal de logs segue: um container de um pod ou, com
+// selector, todos os pods de um workload. Trocar de container ou de
+// instância reinicia o stream sem fechar o modal.
+type logSource struct {
+	clientset  kubernetes.Interface
+	target     data.LogTarget
+	containers []data.Container
+	previous   bool
+	rng        logRange
+	stamps     bool
+	owner      string // kind/nome do workload ou service
+	selector   string // pods do owner
+}
+
+// openLogs segue os logs do pod; com mais de um container (init e efêmeros
+// incluídos) pergunta qual antes de abrir o modal.
+func (d *Dashboard) openLogs(name, targetNS string) {
+	if name == "" {
+		return
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(targetNS) != "" {
+		nsUse = targetNS
+	}
+	src := &logSource{
+		clientset: d.selectionSession().Clientset,
+		target:    data.LogTarget{Namespace: nsUse, Pod: name},
+		rng:       logRanges[0],
+	}
+	go func() {
+		containers, def, err := data.PodContainers(context.Background(), src.clientset, nsUse, name)
+		_ = d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				d.openModal("LOGS "+name, tview.Escape(err.Error()))
+				return
+			}
+			src.containers = containers
+			src.target.Container = def
+			if len(containers) > 1 {
+				d.pickContainer(src)
+				return
+			}
+			d.showLogs(src)
+		})
+	}()
+}
+
+// openWorkloadLogs junta os logs de todos os pods de um workload ou service.
+func (d *Dashboard) openWorkloadLogs(kind, name, targetNS string) {
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(targetNS) != "" {
+		nsUse = targetNS
+	}
+	src := &logSource{
+		clientset: d.selectionSession().Clientset,
+		target:    data.LogTarget{Namespace: nsUse},
+		rng:       logRanges[0],
+		owner:     kind + "/" + name,
+	}
+	go func() {
+		selector, err := data.PodSelector(context.Background(), src.clientset, kind, nsUse, name)
+		_ = d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				d.openModal("LOGS "+src.owner, tview.Escape(err.Error()))
+				return
+			}
+			src.selector = selector
+			d.showLogs(src)
+		})
+	}()
+}
+
+// pickContainer escolhe o container de src; o padrão do pod vem primeiro.
+func (d *Dashboard) pickContainer(src *logSource) {
+	if src.selector != "" {
+		go d.showInfo("Logs de " + src.owner + ": todos os containers de cada pod")
+		return
+	}
+	if len(src.containers) < 2 {
+		go d.showInfo("O pod tem um único container")
+		return
+	}
+	d.openPicker("CONTAINERS "+src.target.Pod, "", func(query string) data.Table {
+		return containerTable(src.containers, query, src.target.Container)
+	}, func(row data.Row) {
+		src.target.Container = row.Name
+		d.showLogs(src)
+	})
+}
+
+// containerTable é a lista do picker de containers, com estado, restarts e
+// instância anterior; current vem primeiro.
+func containerTable(containers []data.Container, query, current string) data.Table {
+	rows := make([]data.Row, 0, len(containers))
+	for _, c := range containers {
+		previous := ""
+		if c.Previous {
+			previous = "yes"
+		}
+		rows = append(rows, data.Row{
+			Kind:    "container",
+			Name:    c.Name,
+			Status:  c.State,
+			Health:  containerHealth(c.State),
+			Columns: []string{c.Name, c.Type, c.State, fmt.Sprint(c.Restarts), previous},
+		})
+	}
+	return data.Table{
+		Kind:   "container",
+		Header: []string{"NAME", "TYPE", "STATE", "RESTARTS", "PREVIOUS"},
+		Rows:   rankRows(query, rows, func(r data.Row) string { return r.Name }, []string{current}),
+	}
+}
+
+func containerHealth(state string) data.Health {
+	switch state {
+	case "Running", "Completed", "":
+		return data.HealthOK
+	case "ContainerCreating", "PodInitializing":
+		return data.HealthWarning
+	}
+	return data.HealthError
+}
+
+// showLogs abre (ou reaproveita) o modal de logs para src.
+func (d *Dashboard) showLogs(src *logSource) {
+	if !d.modalOpen {
+		d.openModal("LOGS "+src.target.Pod+src.owner, "Carregando logs...")
+	}
+	d.modalLogs.SetRegions(true) // ocorrências da busca
+	d.logSource = src
+	d.restartLogs()
+}
+
+// restartLogs troca o stream do modal pelo de d.logSource.
+func (d *Dashboard) restartLogs() {
+	if d.logStream != nil {
+		d.logStream.stop()
+	}
+	src := d.logSource
+	opts := src.rng.options(src.previous)
+	d.modalLogs.SetText("")
+	d.modalLogs.ScrollToEnd()
+	if src.selector != "" {
+		d.logStream = newLogStream(d.app, d.modalLogs, src.owner+" · "+src.rng.label, true)
+		d.logStream.stamps = src.stamps
+		go d.logStream.runSelector(src.clientset, src.target.Namespace, src.selector, opts)
+		return
+	}
+	name := src.target.Pod + "/" + src.target.Container
+	if src.previous {
+		name += " (previous)"
+	}
+	d.logStream = newLogStream(d.app, d.modalLogs, name+" · "+src.rng.label, false)
+	d.logStream.stamps = src.stamps
+	go d.logStream.run(src.clientset, src.target, opts)
+}
+
+// pickLogRange troca o recorte inicial do log e reinicia o stream.
+func (d *Dashboard) pickLogRange() {
+	src := d.logSource
+	d.openPicker("LOG RANGE", "", func(query string) data.Table {
+		describe := map[string]string{
+			logRanges[0].label: fmt.Sprintf("últimas %d linhas", logTail),
+			"5m":               "--since=5m",
+			"1h":               "--since=1h",
+			"since restart":    "log inteiro da instância atual",
+			"custom":           "início em RFC3339 (--since-time) ou uma duração, ex.: 30m",
+		}
+		var rows []data.Row
+		for _, r := range append(logRanges, logRange{label: "custom"}) {
+			rows = append(rows, data.Row{Kind: "range", Name: r.label, Columns: []string{r.label, describe[r.label]}})
+		}
+		return data.Table{
+			Kind:   "range",
+			Header: []string{"RANGE", "DESCRIPTION"},
+			Rows:   rankRows(query, rows, func(r data.Row) string { return r.Name }, nil),
+		}
+	}, func(row data.Row) {
+		if row.Name == "custom" {
+			d.openCustomLogRange(src)
+			return
+		}
+		for _, r := range logRanges {
+			if r.label == row.Name {
+				src.rng = r
+			}
+		}
+		d.restartLogs()
+	})
+}
+
+// openCustomLogRange lê o início como RFC3339 ou como duração até agora.
+func (d *Dashboard) openCustomLogRange(src *logSource) {
+	initial := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	d.openPrompt("since (RFC3339 ou duração): ", initial, nil, func(text string, accepted bool) {
+		text = strings.TrimSpace(text)
+		if !accepted || text == "" {
+			return
+		}
+		if start, err := time.Parse(time.RFC3339, text); err == nil {
+			src.rng = logRange{label: "since " + start.UTC().Format(time.RFC3339), start: start}
+		} else if dur, err := time.ParseDuration(text); err == nil && dur > 0 {
+			src.rng = logRange{label: text, since: dur}
+		} else {
+			go d.showInfo(fmt.Sprintf("Início inválido: %q (use RFC3339, ex.: %s, ou 30m)", text, initial))
+			return
+		}
+		if d.logSource == src {
+			d.restartLogs()
+		}
+	})
+}
+
+// handleLogKeys trata as teclas do modal de logs; a rolagem fica com o TextView.
+func (d *Dashboard) handleLogKeys(ev *tcell.EventKey) *tcell.EventKey {
+	switch ev.Key() {
+	case tcell.KeyUp, tcell.KeyPgUp, tcell.KeyCtrlB, tcell.KeyHome:
+		d.logStream.setFollow(false)
+	case tcell.KeyEnd:
+		d.logStream.setFollow(true)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'p':
+			d.logStream.togglePause()
+			return nil
+		case '/':
+			d.openLogSearch()
+			return nil
+		case 'n', 'N':
+			delta := 1
+			if ev.Rune() == 'N' {
+				delta = -1
+			}
+			d.logStream.setFollow(false)
+			d.logStream.nextMatch(delta)
+			return nil
+		case 'r':
+			d.logStream.toggleRaw()
+			return nil
+		case 'w':
+			d.saveModal()
+			return nil
+		case 'W':
+			d.saveLogBundle(d.logSource)
+			return nil
+		case 's':
+			d.pickLogRange()
+			return nil
+		case 't':
+			d.logSource.stamps = !d.logSource.stamps
+			d.logStream.setTimestamps(d.logSource.stamps)
+			return nil
+		case 'c':
+			d.pickContainer(d.logSource)
+			return nil
+		case 'P':
+			if d.logSource.selector != "" {
+				return nil
+			}
+			d.logSource.previous = !d.logSource.previous
+			d.restartLogs()
+			return nil
+		case 'k', 'g':
+			d.logStream.setFollow(false)
+		case 'G':
+			d.logStream.setFollow(true)
+		}
+	}
+	return ev
+}
+
+// openLogSearch busca no modal de logs enquanto se digita; Esc desliga a busca.
+func (d *Dashboard) openLogSearch() {
+	stream := d.logStream
+	stream.mu.Lock()
+	query := stream.query
+	stream.mu.Unlock()
+	d.openPrompt("/", query, func(text string) {
+		stream.setFollow(false)
+		stream.setSearch(text)
+	}, func(text string, accepted bool) {
+		if !accepted {
+			stream.setSearch("")
+		}
+	})
+}
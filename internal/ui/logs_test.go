@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"ktwins/internal/data"
+)
+
+func ringTexts(r *logRing) []string {
+	var out []string
+	for _, l := range r.all() {
+		out = append(out, l.Text)
+	}
+	return out
+}
+
+func TestLogRingInsert(t *testing.T) {
+	base := time.Date(2025, 11, 30, 14, 0, 0, 0, time.UTC)
+	line := func(text string, sec int) data.LogLine {
+		return data.LogLine{Text: text, Time: base.Add(time.Duration(sec) * time.Second)}
+	}
+	tests := []struct {
+		name     string
+		capacity int
+		in       []data.LogLine
+		want     []string
+	}{
+		{"in order", 5, []data.LogLine{line("a", 1), line("b", 2), line("c", 3)}, []string{"a", "b", "c"}},
+		{"late tail", 5, []data.LogLine{line("b", 2), line("d", 4), line("a", 1), line("c", 3)}, []string{"a", "b", "c", "d"}},
+		{"equal times keep arrival order", 5, []data.LogLine{line("a", 1), line("b", 1), line("c", 1)}, []string{"a", "b", "c"}},
+		{"full drops the oldest", 3, []data.LogLine{line("a", 1), line("c", 3), line("d", 4), line("b", 2)}, []string{"b", "c", "d"}},
+		{"full drops a line older than all", 3, []data.LogLine{line("b", 2), line("c", 3), line("d", 4), line("a", 1)}, []string{"b", "c", "d"}},
+		{"wrapped", 3, []data.LogLine{line("a", 1), line("b", 2), line("d", 4), line("e", 5), line("c", 3)}, []string{"c", "d", "e"}},
+		{"no timestamp stays where it arrived", 5, []data.LogLine{line("b", 2), {Text: "x"}, line("a", 1)}, []string{"b", "x", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLogRing(tt.capacity)
+			for _, l := range tt.in {
+				r.insert(l)
+			}
+			if got := ringTexts(r); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestView é o modal [y]: o objeto vivo em YAML (ou JSON) com um cursor
+// de linha para dobrar e desdobrar seções.
+type manifestView struct {
+	kind, name string
+	root       *data.ManifestNode
+	json       bool
+	hide       bool // sem metadata.managedFields e status
+	folded     map[*data.ManifestNode]bool
+	cursor     int
+
+	// saída do último render: texto e nó de cada linha
+	text  []string
+	lines []*data.ManifestNode
+}
+
+func (d *Dashboard) openManifestSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
+		return
+	}
+	d.openManifest(row.Kind, row.Name, row.Namespace)
+}
+
+// openManifest busca o objeto na sessão de onde ele veio e o mostra em YAML.
+func (d *Dashboard) openManifest(kind, name, targetNS string) {
+	if name == "" || kind == "" {
+		return
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(targetNS) != "" {
+		nsUse = targetNS
+	}
+	m := &manifestView{kind: kind, name: name, folded: map[*data.ManifestNode]bool{}}
+	d.modalFile = fmt.Sprintf("%s_%s_%s", fileNS(nsUse), kind, name)
+	d.openModal(fmt.Sprintf("YAML %s/%s", kind, name), "Carregando manifesto...")
+	d.manifest = m
+	session := d.selectionSession()
+
+	go func() {
+		root, err := data.FetchManifest(context.Background(), session.Config, kind, name, nsUse)
+		_ = d.app.QueueUpdateDraw(func() {
+			if d.manifest != m {
+				return // modal já fechado
+			}
+			if err != nil {
+				d.modalLogs.SetText(tview.Escape(err.Error()))
+				return
+			}
+			m.root = root
+			d.modalLogs.SetRegions(true) // linha do cursor
+			d.renderManifest()
+		})
+	}()
+}
+
+func (d *Dashboard) renderManifest() {
+	m := d.manifest
+	m.render()
+	d.modalLogs.SetTitle(m.title())
+	d.modalLogs.SetText(strings.Join(m.text, "\n"))
+	d.modalLogs.Highlight("cursor").ScrollToHighlight()
+}
+
+// handleManifestKeys trata as teclas do modal de manifesto.
+func (d *Dashboard) handleManifestKeys(ev *tcell.EventKey) *tcell.EventKey {
+	m := d.manifest
+	if m.root == nil {
+		return ev
+	}
+	_, _, _, height := d.modalLogs.GetInnerRect()
+	move := 0
+	switch ev.Key() {
+	case tcell.KeyUp:
+		move = -1
+	case tcell.KeyDown:
+		move = 1
+	case tcell.KeyPgUp:
+		move = -height
+	case tcell.KeyPgDn:
+		move = height
+	case tcell.KeyHome:
+		move = -len(m.lines)
+	case tcell.KeyEnd:
+		move = len(m.lines)
+	case tcell.KeyEnter:
+		m.toggleFold()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'k':
+			move = -1
+		case 'j':
+			move = 1
+		case 'g':
+			move = -len(m.lines)
+		case 'G':
+			move = len(m.lines)
+		case ' ', 'z':
+			m.toggleFold()
+		case 'Z':
+			m.toggleFoldAll()
+		case 'y':
+			m.json = !m.json
+		case 'h':
+			m.hide = !m.hide
+		case 'w':
+			d.saveModal()
+			return nil
+		default:
+			return ev
+		}
+	default:
+		return ev
+	}
+	m.cursor = max(0, min(m.cursor+move, len(m.lines)-1))
+	d.renderManifest()
+	return nil
+}
+
+func (m *manifestView) title() string {
+	format, other := "YAML", "json"
+	if m.json {
+		format, other = "JSON", "yaml"
+	}
+	hide := "[h]ide managedFields/status"
+	if m.hide {
+		hide = "[h] show managedFields/status"
+	}
+	return fmt.Sprintf("%s %s/%s  [y] %s %s [z] fold [Z] fold all [w] save (Esc fecha)", format, m.kind, m.name, other, hide)
+}
+
+// toggleFold dobra (ou desdobra) a seção da linha do cursor; numa linha de
+// valor simples, dobra a seção que a contém.
+func (m *manifestView) toggleFold() {
+	if m.cursor >= len(m.lines) {
+		return
+	}
+	n := m.lines[m.cursor]
+	if !n.Container() || (len(m.visible(n)) == 0 && !m.folded[n]) {
+		n = n.Parent
+	}
+	if n == nil || n == m.root {
+		return
+	}
+	m.folded[n] = !m.folded[n]
+	m.render()
+	for i, l := range m.lines {
+		if l == n {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// toggleFoldAll desdobra tudo se algo está dobrado; senão dobra as seções
+// abaixo do primeiro nível (metadata.labels, spec.template, ...).
+func (m *manifestView) toggleFoldAll() {
+	for _, on := range m.folded {
+		if on {
+			m.folded = map[*data.ManifestNode]bool{}
+			return
+		}
+	}
+	for _, top := range m.root.Children {
+		for _, c := range top.Children {
+			if c.Container() {
+				m.folded[c] = true
+			}
+		}
+	}
+	m.cursor = 0
+}
+
+// export é o manifesto inteiro (sem dobras nem cores) para gravar com [w],
+// gerado pelos encoders e não pelo render da tela.
+func (m *manifestView) export() (text, ext string, err error) {
+	v := m.value(m.root)
+	if m.json {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(v)
+		return buf.String(), ".json", err
+	}
+	out, err := yaml.Marshal(v)
+	return string(out), ".yaml", err
+}
+
+// value remonta o nó como valor Go, sem as seções escondidas por [h].
+func (m *manifestView) value(n *data.ManifestNode) any {
+	switch {
+	case n.Map:
+		out := map[string]any{}
+		for _, c := range m.visible(n) {
+			out[c.Key] = m.value(c)
+		}
+		return out
+	case n.List:
+		out := make([]any, 0, len(n.Children))
+		for _, c := range n.Children {
+			out = append(out, m.value(c))
+		}
+		return out
+	}
+	return n.Value
+}
+
+func (m *manifestView) render() {
+	m.text, m.lines = nil, nil
+	if m.json {
+		m.jsonValue(m.root, 0, "", "")
+	} else {
+		m.yamlMap(m.root, 0, "", nil)
+	}
+	if m.cursor >= len(m.lines) {
+		m.cursor = len(m.lines) - 1
+	}
+	if m.cursor >= 0 {
+		m.text[m.cursor] = `["cursor"]` + m.text[m.cursor] + `[""]`
+	}
+}
+
+func (m *manifestView) emit(text string, n *data.ManifestNode) {
+	m.text = append(m.text, text)
+	m.lines = append(m.lines, n)
+}
+
+func (m *manifestView) visible(n *data.ManifestNode) []*data.ManifestNode {
+	if !m.hide {
+		return n.Children
+	}
+	var out []*data.ManifestNode
+	for _, c := range n.Children {
+		if c.Path != "status" && c.Path != "metadata.managedFields" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// paint colore s, escapado para o tview.
+func (m *manifestView) paint(color, s string) string {
+	return "[" + color + "]" + tview.Escape(s) + "[-]"
+}
+
+// lit escapa texto sem cor (colchetes de listas JSON/YAML).
+func (m *manifestView) lit(s string) string {
+	return tview.Escape(s)
+}
+
+func (m *manifestView) foldMark(n *data.ManifestNode) string {
+	if n.Map {
+		return m.paint("gray", fmt.Sprintf("{…} (%d)", len(m.visible(n))))
+	}
+	return m.paint("gray", fmt.Sprintf("[…] (%d)", len(m.visible(n))))
+}
+
+func (m *manifestView) scalar(v any, text string) string {
+	switch v.(type) {
+	case string:
+		return m.paint("green", text)
+	case nil:
+		return m.paint("gray", text)
+	}
+	return m.paint("yellow", text)
+}
+
+// yamlMap escreve as entradas do mapa n; lead, se houver, abre a primeira
+// linha ("- " de item de lista) e essa linha pertence a leadNode.
+func (m *manifestView) yamlMap(n *data.ManifestNode, indent int, lead string, leadNode *data.ManifestNode) {
+	for i, c := range m.visible(n) {
+		pad, owner := strings.Repeat(" ", indent), c
+		if i == 0 && lead != "" {
+			pad, owner = lead, leadNode
+		}
+		m.yamlValue(c, indent, pad+m.paint("skyblue", yamlString(c.Key))+":", owner)
+	}
+}
+
+// yamlValue escreve n depois de head (chave ou "-") e, nas linhas de baixo,
+// o conteúdo dele.
+func (m *manifestView) yamlValue(n *data.ManifestNode, indent int, head string, owner *data.ManifestNode) {
+	children := m.visible(n)
+	switch {
+	case n.Container() && m.folded[n]:
+		m.emit(head+" "+m.foldMark(n), owner)
+	case n.Map && len(children) == 0:
+		m.emit(head+" {}", owner)
+	case n.List && len(children) == 0:
+		m.emit(head+" "+m.lit("[]"), owner)
+	case n.Map:
+		m.emit(head, owner)
+		m.yamlMap(n, indent+2, "", nil)
+	case n.List:
+		m.emit(head, owner)
+		m.yamlList(n, indent)
+	default:
+		first, block := yamlScalar(n.Value)
+		m.emit(head+" "+m.scalar(n.Value, first), owner)
+		for _, l := range block {
+			m.emit(m.scalar(n.Value, strings.Repeat(" ", indent+2)+l), owner)
+		}
+	}
+}
+
+// yamlList escreve os itens no mesmo recuo da chave, como o kubectl.
+func (m *manifestView) yamlList(n *data.ManifestNode, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, item := range n.Children {
+		if item.Map && len(m.visible(item)) > 0 && !m.folded[item] {
+			m.yamlMap(item, indent+2, pad+"- ", item)
+			continue
+		}
+		m.yamlValue(item, indent+2, pad+"-", item)
+	}
+}
+
+func (m *manifestView) jsonValue(n *data.ManifestNode, indent int, key, comma string) {
+	pad := strings.Repeat(" ", indent)
+	head := pad
+	if key != "" {
+		head += m.paint("skyblue", jsonString(key)) + ": "
+	}
+	children := m.visible(n)
+	open, end := "{", "}"
+	if n.List {
+		open, end = "[", "]"
+	}
+	switch {
+	case n.Container() && m.folded[n]:
+		m.emit(head+m.foldMark(n)+comma, n)
+	case n.Container() && len(children) == 0:
+		m.emit(head+m.lit(open+end)+comma, n)
+	case n.Container():
+		m.emit(head+m.lit(open), n)
+		for i, c := range children {
+			sep := ","
+			if i == len(children)-1 {
+				sep = ""
+			}
+			childKey := ""
+			if n.Map {
+				childKey = c.Key
+			}
+			m.jsonValue(c, indent+2, childKey, sep)
+		}
+		m.emit(pad+m.lit(end)+comma, n)
+	default:
+		text := "null"
+		switch v := n.Value.(type) {
+		case string:
+			text = jsonString(v)
+		case nil:
+		default:
+			text = fmt.Sprint(v)
+		}
+		m.emit(head+m.scalar(n.Value, text)+comma, n)
+	}
+}
+
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+var (
+	yamlPlain    = regexp.MustCompile(`^[A-Za-z0-9_./@][A-Za-z0-9_./@=+:,() -]*$`)
+	yamlReserved = regexp.MustCompile(`(?i)^(true|false|yes|no|on|off|y|n|null|~)$`)
+	yamlDate     = regexp.MustCompile(`^\d{4}-\d\d-\d\d`)
+)
+
+// yamlScalar formata um escalar para a tela, parecido com o kubectl: texto
+// simples quando não há ambiguidade, bloco "|" para várias linhas e aspas no
+// resto. Arquivos e diffs saem do export.
+func yamlScalar(v any) (first string, block []string) {
+	switch val := v.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		if strings.Contains(val, "\n") && !strings.ContainsAny(val, "\r\t") {
+			header := "|-"
+			if strings.HasSuffix(val, "\n") {
+				header, val = "|", strings.TrimSuffix(val, "\n")
+			}
+			return header, strings.Split(val, "\n")
+		}
+		return yamlString(val), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+func yamlString(s string) string {
+	if _, err := strconv.ParseFloat(s, 64); err == nil ||
+		!yamlPlain.MatchString(s) || yamlReserved.MatchString(s) || yamlDate.MatchString(s) ||
+		strings.Contains(s, ": ") || strings.HasSuffix(s, ":") || strings.HasSuffix(s, " ") {
+		return jsonString(s)
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+)
+
+const maxRecentNamespaces = 10
+
+// openPicker abre um popup de escolha com busca fuzzy: items monta a lista
+// para a busca atual, ↑/↓ movem e Enter entrega a linha escolhida a pick.
+func (d *Dashboard) openPicker(title, query string, items func(query string) data.Table, pick func(row data.Row)) {
+	if d.pickerOpen {
+		return
+	}
+	restore := d.app.GetFocus()
+	list := newListView("", false)
+	list.SetBorder(false)
+	input := tview.NewInputField().
+		SetLabel("> ").
+		SetText(query).
+		SetLabelColor(tcell.ColorSkyblue).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	render := func(q string) {
+		list.SetTables([]data.Table{items(q)})
+		list.startBrowse() // melhor resultado selecionado
+	}
+	closePicker := func() {
+		d.pickerOpen = false
+		d.pages.RemovePage("picker")
+		if restore != nil {
+			d.app.SetFocus(restore)
+		}
+	}
+	input.SetChangedFunc(render)
+	input.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyUp:
+			list.move(-1)
+			return nil
+		case tcell.KeyDown:
+			list.move(1)
+			return nil
+		}
+		return ev
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			row := list.selectedRow()
+			closePicker()
+			if row != nil {
+				pick(*row)
+			}
+		case tcell.KeyEscape:
+			closePicker()
+		}
+	})
+
+	box := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	box.SetBorder(true).SetTitle(" " + title + " (Enter escolhe, Esc fecha) ")
+	popup := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(box, 20, 0, true).
+			AddItem(nil, 0, 1, false), 90, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	render(query)
+	d.pickerOpen = true
+	d.pages.AddPage("picker", popup, true, true)
+	d.app.SetFocus(input)
+}
+
+// openNamespacePicker troca de namespace (":ns" ou Ctrl+N), recentes primeiro.
+func (d *Dashboard) openNamespacePicker(query string) {
+	d.openPicker("NAMESPACES", query, d.pickNamespaces, func(row data.Row) {
+		d.useNamespace(row.Name)
+	})
+}
+
+// openContextPicker troca de contexto do kubeconfig (":ctx" ou Ctrl+K).
+func (d *Dashboard) openContextPicker(query string) {
+	d.openPicker("CONTEXTS", query, d.pickContexts, func(row data.Row) {
+		d.switchContext(row.Name)
+	})
+}
+
+func (d *Dashboard) pickNamespaces(query string) data.Table {
+	all := append([]data.Row{{Kind: "ns", Columns: []string{"ALL", "", ""}}}, d.nsTable.Rows...)
+	return data.Table{
+		Kind:   "ns",
+		Header: []string{"NAME", "STATUS", "AGE"},
+		Rows:   rankRows(query, all, func(r data.Row) string { return displayNS(r.Name) }, d.recentNamespaces()),
+	}
+}
+
+// pickContexts lista os contextos como "kubectl config get-contexts".
+func (d *Dashboard) pickContexts(query string) data.Table {
+	var rows []data.Row
+	for _, c := range d.kubeconfig.Contexts() {
+		current := ""
+		if c.Name == d.session.Name {
+			current = "*"
+		}
+		rows = append(rows, data.Row{
+			Kind:    "context",
+			Name:    c.Name,
+			Columns: []string{current, c.Name, c.Cluster, c.User, c.Namespace},
+		})
+	}
+	return data.Table{
+		Kind:   "context",
+		Header: []string{"CURRENT", "NAME", "CLUSTER", "AUTHINFO", "NAMESPACE"},
+		Rows:   rankRows(query, rows, func(r data.Row) string { return r.Name }, nil),
+	}
+}
+
+// rankRows mantém as linhas que casam com query: recentes primeiro, depois
+// pela pontuação fuzzy e pelo nome.
+func rankRows(query string, rows []data.Row, label func(data.Row) string, recent []string) []data.Row {
+	rank := map[string]int{}
+	for i, name := range recent {
+		rank[name] = i
+	}
+	type candidate struct {
+		row    data.Row
+		score  int
+		recent int
+	}
+	var found []candidate
+	for _, r := range rows {
+		score, ok := fuzzyScore(query, label(r))
+		if !ok {
+			continue
+		}
+		pos, isRecent := rank[r.Name]
+		if !isRecent {
+			pos = len(rank)
+		}
+		found = append(found, candidate{row: r, score: score, recent: pos})
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.recent != b.recent {
+			return a.recent < b.recent
+		}
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.row.Name < b.row.Name
+	})
+	out := make([]data.Row, 0, len(found))
+	for _, c := range found {
+		out = append(out, c.row)
+	}
+	return out
+}
+
+func (d *Dashboard) recentNamespaces() []string {
+	if d.cfg == nil {
+		return nil
+	}
+	return d.cfg.RecentNamespaces[d.session.Server]
+}
+
+// rememberNamespace põe ns no topo dos recentes do cluster.
+func (d *Dashboard) rememberNamespace(ns string) {
+	if d.cfg == nil || ns == "" {
+		return
+	}
+	recent := []string{ns}
+	for _, n := range d.cfg.RecentNamespaces[d.session.Server] {
+		if n != ns && len(recent) < maxRecentNamespaces {
+			recent = append(recent, n)
+		}
+	}
+	d.cfg.RecentNamespaces[d.session.Server] = recent
+}
+
+// fuzzyScore casa pattern como subsequência de s (sem diferenciar
+// maiúsculas); letras seguidas e início de palavra valem mais.
+func fuzzyScore(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(strings.TrimSpace(pattern)))
+	if len(p) == 0 {
+		return 0, true
+	}
+	text := []rune(strings.ToLower(s))
+	score, pi, last := 0, 0, -2
+	for i, r := range text {
+		if pi == len(p) {
+			break
+		}
+		if r != p[pi] {
+			continue
+		}
+		score++
+		switch {
+		case i == last+1:
+			score += 5
+		case i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]):
+			score += 3
+		}
+		last = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score - len(text)/4, true
+}
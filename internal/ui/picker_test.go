@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"ktwins/internal/data"
+)
+
+func TestFuzzyScoreMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"", "anything", true},
+		{"  ", "anything", true},
+		{"pay", "payments", true},
+		{"PAY", "payments", true},
+		{"pmt", "payments", true},
+		{"kbs", "kube-system", true},
+		{"tmp", "payments", false},
+		{"paymentss", "payments", false},
+		{"x", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.s, func(t *testing.T) {
+			if _, ok := fuzzyScore(tt.pattern, tt.s); ok != tt.want {
+				t.Errorf("fuzzyScore(%q, %q) ok = %v, want %v", tt.pattern, tt.s, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreOrder(t *testing.T) {
+	tests := []struct {
+		pattern, better, worse string
+	}{
+		{"pay", "payments", "prod-analytics-yarn"}, // letras seguidas
+		{"sys", "my-sys", "mysys"},                 // início de palavra
+		{"web", "web", "web-frontend-staging"},     // nome mais curto
+	}
+	for _, tt := range tests {
+		b, okB := fuzzyScore(tt.pattern, tt.better)
+		w, okW := fuzzyScore(tt.pattern, tt.worse)
+		if !okB || !okW {
+			t.Fatalf("%q: both should match (%v, %v)", tt.pattern, okB, okW)
+		}
+		if b <= w {
+			t.Errorf("%q: score(%q) = %d, want > score(%q) = %d", tt.pattern, tt.better, b, tt.worse, w)
+		}
+	}
+}
+
+func TestRankRows(t *testing.T) {
+	var rows []data.Row
+	for _, name := range []string{"default", "kube-system", "payments", "payments-staging", "platform"} {
+		rows = append(rows, data.Row{Name: name})
+	}
+	name := func(r data.Row) string { return r.Name }
+	names := func(rs []data.Row) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+	tests := []struct {
+		name   string
+		query  string
+		recent []string
+		want   []string
+	}{
+		{"empty query keeps name order", "", nil, []string{"default", "kube-system", "payments", "payments-staging", "platform"}},
+		{"recent first", "", []string{"platform", "default"}, []string{"platform", "default", "kube-system", "payments", "payments-staging"}},
+		{"best score first", "pay", nil, []string{"payments", "payments-staging"}},
+		{"recent beats score", "pay", []string{"payments-staging"}, []string{"payments-staging", "payments"}},
+		{"no match", "zzz", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(rankRows(tt.query, rows, name, tt.recent))
+			if got == nil {
+				got = []string{}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rankRows(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
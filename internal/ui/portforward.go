@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"ktwins/internal/kube"
+)
+
+const forwardRefresh = time.Second // contadores de bytes no painel aberto
+
+// openForwardSelected abre um port-forward a partir do pod ou service
+// selecionado [f]: escolhe uma porta declarada e depois a porta local.
+func (d *Dashboard) openForwardSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
+		return
+	}
+	if !data.ForwardKinds[row.Kind] {
+		go d.showInfo("Port-forward: selecione um pod ou service")
+		return
+	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(row.Namespace) != "" {
+		nsUse = row.Namespace
+	}
+	session := d.selectionSession()
+	ref := row.Kind + "/" + row.Name
+	go func() {
+		pod, ports, err := data.ForwardTarget(context.Background(), session.Clientset, row.Kind, nsUse, row.Name)
+		_ = d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				go d.showInfo(fmt.Sprintf("Erro em %s: %v", ref, err))
+				return
+			}
+			start := func(local, remote int) {
+				via := ""
+				if row.Kind == "svc" {
+					via = ref
+				}
+				d.startForward(session, nsUse, pod, via, local, remote)
+			}
+			if len(ports) == 0 {
+				d.askForwardPorts(ref, start)
+				return
+			}
+			d.pickForwardPort(ref, ports, start)
+		})
+	}()
+}
+
+// pickForwardPort lista as portas declaradas; a escolhida pergunta a porta
+// local, partindo da mesma.
+func (d *Dashboard) pickForwardPort(ref string, ports []data.ForwardPort, start func(local, remote int)) {
+	d.openPicker("PORT-FORWARD "+ref, "", func(query string) data.Table {
+		rows := make([]data.Row, 0, len(ports))
+		for i, p := range ports {
+			port := strconv.Itoa(int(p.Port))
+			rows = append(rows, data.Row{
+				Kind:    "port",
+				Name:    port + " " + p.Name,
+				UID:     strconv.Itoa(i),
+				Columns: []string{port, p.Name, strconv.Itoa(int(p.Remote)), p.Container},
+			})
+		}
+		return data.Table{
+			Kind:   "port",
+			Header: []string{"PORT", "NAME", "POD PORT", "CONTAINER"},
+			Rows:   rankRows(query, rows, func(r data.Row) string { return r.Name }, nil),
+		}
+	}, func(row data.Row) {
+		i, _ := strconv.Atoi(row.UID)
+		p := ports[i]
+		label := fmt.Sprintf("porta local para %s:%d (0 = livre): ", ref, p.Port)
+		d.openPrompt(label, strconv.Itoa(int(p.Port)), nil, func(text string, accepted bool) {
+			if !accepted {
+				return
+			}
+			local, err := strconv.Atoi(strings.TrimSpace(text))
+			if err != nil || local < 0 || local > 65535 {
+				go d.showInfo(fmt.Sprintf("Porta inválida: %q", text))
+				return
+			}
+			start(local, int(p.Remote))
+		})
+	})
+}
+
+// askForwardPorts pede local:remota quando o objeto não declara portas.
+func (d *Dashboard) askForwardPorts(ref string, start func(local, remote int)) {
+	label := fmt.Sprintf("%s não declara portas; local:remota (ex.: 8080:80): ", ref)
+	d.openPrompt(label, "", nil, func(text string, accepted bool) {
+		if !accepted {
+			return
+		}
+		local, remote, ok := parseForwardPorts(text)
+		if !ok {
+			go d.showInfo(fmt.Sprintf("Portas inválidas: %q", text))
+			return
+		}
+		start(local, remote)
+	})
+}
+
+// parseForwardPorts aceita "local:remota" ou só a porta, usada dos dois lados.
+func parseForwardPorts(text string) (local, remote int, ok bool) {
+	localText, remoteText, found := strings.Cut(strings.TrimSpace(text), ":")
+	if !found {
+		remoteText = localText
+	}
+	local, errL := strconv.Atoi(localText)
+	remote, errR := strconv.Atoi(remoteText)
+	if errL != nil || errR != nil || local < 0 || local > 65535 || remote < 1 || remote > 65535 {
+		return 0, 0, false
+	}
+	return local, remote, true
+}
+
+func (d *Dashboard) startForward(session *kube.Session, namespace, pod, via string, local, remote int) {
+	go func() {
+		f, err := data.StartPortForward(session.Config, session.Clientset, session.Name, namespace, pod, local, remote)
+		if err != nil {
+			d.showInfo(fmt.Sprintf("Port-forward para pods/%s:%d: %v", pod, remote, err))
+			return
+		}
+		f.Via = via
+		_ = d.app.QueueUpdateDraw(func() {
+			d.forwardSeq++
+			f.ID = d.forwardSeq
+			d.forwards = append(d.forwards, f)
+		})
+		d.showInfo(fmt.Sprintf("Port-forward 127.0.0.1:%d → %s ([F] lista)", f.Local, f.Target()))
+	}()
+}
+
+// openForwards mostra o painel PORT-FORWARDS [F]: os encaminhamentos
+// abertos com os bytes trafegados; [x] encerra o selecionado.
+func (d *Dashboard) openForwards() {
+	if d.overlayOpen() {
+		return
+	}
+	if len(d.forwards) == 0 {
+		go d.showInfo("Nenhum port-forward aberto; [f] num pod ou service abre um")
+		return
+	}
+	restore := d.app.GetFocus()
+	list := newListView("", false)
+	list.SetBorder(false)
+	box := tview.NewFlex().SetDirection(tview.FlexRow).AddItem(list, 0, 1, true)
+	box.SetBorder(true).SetBorderColor(tcell.ColorWheat).
+		SetTitle(" PORT-FORWARDS  [x] encerra / remove  (Esc fecha) ")
+
+	refresh := func() {
+		selected := ""
+		if r := list.selectedRow(); r != nil {
+			selected = r.UID
+		}
+		list.SetTables([]data.Table{data.ForwardTable(d.forwards)})
+		list.startBrowse()
+		for row := range list.GetRowCount() {
+			if r := list.rowAt(row); r != nil && r.UID == selected {
+				list.selectRow(row)
+			}
+		}
+	}
+	stopTicker := make(chan struct{})
+	closeForwards := func() {
+		close(stopTicker)
+		d.pages.RemovePage("forwards")
+		d.panelOpen = false
+		if restore != nil {
+			d.app.SetFocus(restore)
+		}
+	}
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case ev.Key() == tcell.KeyEscape:
+			closeForwards()
+			return nil
+		case ev.Key() == tcell.KeyUp:
+			list.move(-1)
+			return nil
+		case ev.Key() == tcell.KeyDown:
+			list.move(1)
+			return nil
+		case ev.Key() == tcell.KeyDelete, ev.Key() == tcell.KeyRune && ev.Rune() == 'x':
+			if f := d.forwardAt(list.selectedRow()); f != nil {
+				d.stopForward(f, refresh)
+			}
+			return nil
+		}
+		return ev
+	})
+	go func() {
+		t := time.NewTicker(forwardRefresh)
+		defer t.Stop()
+		for {
+			select {
+			case <-stopTicker:
+				return
+			case <-t.C:
+				_ = d.app.QueueUpdateDraw(func() {
+					select {
+					case <-stopTicker: // fechado enquanto esperava a vez
+					default:
+						refresh()
+					}
+				})
+			}
+		}
+	}()
+
+	refresh()
+	// o painel é modal: handleInput deixa as teclas com ele
+	d.panelOpen = true
+	d.pages.AddPage("forwards", box, true, true)
+	d.app.SetFocus(list)
+}
+
+func (d *Dashboard) forwardAt(row *data.Row) *data.PortForward {
+	if row == nil {
+		return nil
+	}
+	for _, f := range d.forwards {
+		if strconv.Itoa(f.ID) == row.UID {
+			return f
+		}
+	}
+	return nil
+}
+
+// stopForward encerra um port-forward rodando; um já encerrado sai da lista.
+func (d *Dashboard) stopForward(f *data.PortForward, refresh func()) {
+	if !f.Running() {
+		for i, other := range d.forwards {
+			if other == f {
+				d.forwards = append(d.forwards[:i], d.forwards[i+1:]...)
+				break
+			}
+		}
+		refresh()
+		return
+	}
+	go func() {
+		f.Stop()
+		_ = d.app.QueueUpdateDraw(refresh)
+		d.showInfo(fmt.Sprintf("Port-forward 127.0.0.1:%d encerrado", f.Local))
+	}()
+}
+
+// stopForwards derruba todos os port-forwards ao sair do ktwins.
+func (d *Dashboard) stopForwards() {
+	var wg sync.WaitGroup
+	for _, f := range d.forwards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Stop()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+)
+
+func TestParseForwardPorts(t *testing.T) {
+	tests := []struct {
+		in                string
+		wantLocal, wantRm int
+		wantOK            bool
+	}{
+		{"8080:80", 8080, 80, true},
+		{" 8080:80 ", 8080, 80, true},
+		{"5432", 5432, 5432, true},
+		{"0:80", 0, 80, true},
+		{"8080:0", 0, 0, false},
+		{"0", 0, 0, false},
+		{"70000:80", 0, 0, false},
+		{"8080:70000", 0, 0, false},
+		{"-1:80", 0, 0, false},
+		{"http", 0, 0, false},
+		{"8080:", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			local, remote, ok := parseForwardPorts(tt.in)
+			if local != tt.wantLocal || remote != tt.wantRm || ok != tt.wantOK {
+				t.Errorf("parseForwardPorts(%q) = (%d, %d, %v), want (%d, %d, %v)", tt.in, local, remote, ok, tt.wantLocal, tt.wantRm, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestForwardAtAfterRemoval garante que a linha selecionada continua
+// apontando para o mesmo port-forward depois que outro sai da lista.
+func TestForwardAtAfterRemoval(t *testing.T) {
+	a, b, c := &data.PortForward{ID: 1}, &data.PortForward{ID: 2}, &data.PortForward{ID: 3}
+	d := &Dashboard{forwards: []*data.PortForward{a, b, c}}
+	selected := data.ForwardTable(d.forwards).Rows[2] // c
+
+	d.forwards = []*data.PortForward{b, c} // a parado e removido
+	if got := d.forwardAt(&selected); got != c {
+		t.Errorf("forwardAt = %+v, want the forward with ID 3", got)
+	}
+	gone := data.Row{UID: "1"}
+	if got := d.forwardAt(&gone); got != nil {
+		t.Errorf("forwardAt of a removed forward = %+v, want nil", got)
+	}
+	if d.forwardAt(nil) != nil {
+		t.Error("forwardAt(nil) != nil")
+	}
+}
+
+func TestOpenForwardsBehindOverlay(t *testing.T) {
+	d := &Dashboard{pages: tview.NewPages(), pickerOpen: true, forwards: []*data.PortForward{{ID: 1}}}
+	d.openForwards()
+	if d.pages.HasPage("forwards") || d.panelOpen || !d.pickerOpen {
+		t.Errorf("forwards opened over the picker (panelOpen=%v, pickerOpen=%v)", d.panelOpen, d.pickerOpen)
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+)
+
+// openPrompt troca a barra de atalhos por uma linha de entrada. onChange
+// recebe cada edição; onDone recebe o texto final e se foi confirmado (Enter)
+// ou cancelado (Esc).
+func (d *Dashboard) openPrompt(label, initial string, onChange func(string), onDone func(text string, accepted bool)) {
+	if d.promptOpen {
+		return
+	}
+	restore := d.app.GetFocus()
+	input := tview.NewInputField().
+		SetLabel(label).
+		SetText(initial).
+		SetLabelColor(tcell.ColorSkyblue).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	if onChange != nil {
+		input.SetChangedFunc(onChange)
+	}
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter && key != tcell.KeyEscape {
+			return
+		}
+		d.closePrompt(input, restore)
+		if onDone != nil {
+			onDone(input.GetText(), key == tcell.KeyEnter)
+		}
+	})
+	d.promptOpen = true
+	d.root.RemoveItem(d.pageIndicator)
+	d.root.AddItem(input, 1, 0, true)
+	d.app.SetFocus(input)
+}
+
+func (d *Dashboard) closePrompt(input *tview.InputField, restore tview.Primitive) {
+	d.promptOpen = false
+	d.root.RemoveItem(input)
+	d.root.AddItem(d.pageIndicator, 1, 0, false)
+	if restore != nil {
+		d.app.SetFocus(restore)
+	}
+}
+
+// openFilter filtra o box em foco enquanto se digita; Esc limpa o filtro.
+func (d *Dashboard) openFilter() {
+	box := d.listFor(d.app.GetFocus())
+	if box == nil {
+		return
+	}
+	if box.mirror != nil {
+		go d.showInfo("Twins: as linhas ficam alinhadas por nome, sem filtro")
+		return
+	}
+	apply := func(text string) {
+		box.setFilter(data.ParseFilter(text))
+		d.refreshTitle(box)
+	}
+	d.openPrompt("/", box.filter.String(), apply, func(text string, accepted bool) {
+		if !accepted {
+			text = ""
+		}
+		apply(text)
+		d.highlightFocus()
+	})
+}
+
+// openSelector troca os seletores do dashboard inteiro. Aceita um seletor de
+// labels puro ("app=checkout") ou flags do kubectl ("-l app=checkout
+// --field-selector status.phase=Running"); vazio remove os seletores.
+func (d *Dashboard) openSelector() {
+	d.openPrompt("selector: ", d.scope.Selector(), nil, func(text string, accepted bool) {
+		if !accepted {
+			return
+		}
+		scope := d.scope
+		scope.Labels, scope.Fields = parseSelectorFlags(text)
+		if err := scope.Validate(); err != nil {
+			go d.showInfo(fmt.Sprintf("Seletor inválido: %v", err))
+			return
+		}
+		d.updateMu.Lock()
+		d.scope = scope
+		d.updateMu.Unlock()
+		msg := "Seletor: " + scope.Selector()
+		if !scope.HasSelector() {
+			msg = "Seletor removido"
+		}
+		go d.showInfo(msg)
+		d.exitBrowse()
+		d.scheduleUpdate()
+	})
+}
+
+// parseSelectorFlags separa "-l <labels> --field-selector <fields>"; sem flags, tudo é seletor de labels.
+func parseSelectorFlags(text string) (labelSel, fieldSel string) {
+	text = strings.TrimSpace(text)
+	if before, after, ok := strings.Cut(text, "--field-selector"); ok {
+		text = strings.TrimSpace(before)
+		fieldSel = strings.TrimSpace(strings.TrimPrefix(after, "="))
+	}
+	for _, flag := range []string{"--selector", "-l"} {
+		if strings.HasPrefix(text, flag) {
+			text = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(text, flag), "="))
+			break
+		}
+	}
+	return text, fieldSel
+}
+
+// openCommand lê um comando no estilo ":ns [busca]", ":ctx [busca]" ou ":twins <namespace>".
+func (d *Dashboard) openCommand() {
+	d.openPrompt(":", "", nil, func(text string, accepted bool) {
+		fields := strings.Fields(text)
+		if !accepted || len(fields) == 0 {
+			return
+		}
+		switch fields[0] {
+		case "ns", "namespace", "namespaces":
+			d.openNamespacePicker(strings.Join(fields[1:], " "))
+		case "ctx", "context", "contexts":
+			d.openContextPicker(strings.Join(fields[1:], " "))
+		case "twins", "twin":
+			d.twinCommand(fields[1:])
+		case "pf", "portforward", "portforwards":
+			d.openForwards()
+		default:
+			go d.showInfo(fmt.Sprintf("Comando desconhecido: %s", fields[0]))
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package ui
+
+import "testing"
+
+func TestParseSelectorFlags(t *testing.T) {
+	tests := []struct {
+		in                 string
+		wantLabel, wantFld string
+	}{
+		{"", "", ""},
+		{"app=web", "app=web", ""},
+		{"  app=web,tier!=db  ", "app=web,tier!=db", ""},
+		{"-l app=web", "app=web", ""},
+		{"-l=app=web", "app=web", ""},
+		{"--selector app=web", "app=web", ""},
+		{"--selector=app=web", "app=web", ""},
+		{"--field-selector status.phase=Running", "", "status.phase=Running"},
+		{"--field-selector=status.phase=Running", "", "status.phase=Running"},
+		{"-l app=web --field-selector status.phase=Running", "app=web", "status.phase=Running"},
+		{"app=web --field-selector=spec.nodeName=n1", "app=web", "spec.nodeName=n1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			label, field := parseSelectorFlags(tt.in)
+			if label != tt.wantLabel || field != tt.wantFld {
+				t.Errorf("parseSelectorFlags(%q) = (%q, %q), want (%q, %q)", tt.in, label, field, tt.wantLabel, tt.wantFld)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"ktwins/internal/theme"
+)
+
+// renderText alinha uma tabela como o tabwriter do kubectl, para boxes de texto (EVENTS).
+func renderText(t data.Table) string {
+	if t.Err != nil {
+		return tview.Escape(t.Err.Error())
+	}
+	if len(t.Rows) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 6, 4, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.Header, "\t"))
+	for _, r := range t.Rows {
+		fmt.Fprintln(w, strings.Join(r.Columns, "\t"))
+	}
+	_ = w.Flush()
+	return tview.Escape(strings.TrimSpace(b.String()))
+}
+
+func formatSummary(s data.Summary) string {
+	c := s.Counts
+	ns := displayNS(s.Namespace)
+	if len(s.Namespaces) > 0 {
+		ns = strings.Join(s.Namespaces, ",")
+	}
+	line1 := fmt.Sprintf("%sNS%s %-10s",
+		theme.Title, theme.Reset, tview.Escape(ns))
+	if s.Selector != "" {
+		line1 += fmt.Sprintf(" %sSEL%s %s", theme.Title, theme.Reset, tview.Escape(s.Selector))
+	}
+
+	line2 := fmt.Sprintf("%sinfra%s nodes:%d crd:%d",
+		theme.Header, theme.Reset, c["nodes"], c["crd"])
+
+	line3 := fmt.Sprintf("%sconfig%s sec:%d cm:%d sa:%d",
+		theme.Header, theme.Reset, c["secrets"], c["configmaps"], c["serviceaccounts"])
+
+	line4 := fmt.Sprintf("%snet%s svc:%d ing:%d ep:%d",
+		theme.Header, theme.Reset, c["svc"], c["ingress"], c["endpoints"])
+
+	line5 := fmt.Sprintf("%sstorage%s pvc:%d pv:%d",
+		theme.Header, theme.Reset, c["pvc"], c["pv"])
+
+	line6 := fmt.Sprintf("%sworkloads%s d:%d rs:%d sts:%d ds:%d jobs:%d cj:%d",
+		theme.Header, theme.Reset, c["deploy"], c["rs"], c["sts"], c["ds"], c["jobs"], c["cronjobs"])
+
+	line7 := fmt.Sprintf("%spods%s %d",
+		theme.Header, theme.Reset, c["pods"])
+
+	return strings.Join([]string{line1, line2, line3, line4, line5, line6, line7}, "\n")
+}
+
+func formatAlerts(rows []data.Row) string {
+	var b strings.Builder
+	for _, r := range rows {
+		fmt.Fprintf(&b, "%s⚠ %s: %s%s\n", theme.Red, tview.Escape(r.Name), r.Status, theme.Reset)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// namespaceTable numera os namespaces para os atalhos 0-9 (0 é ALL) e marca
+// o que está em uso: ✓ para o conjunto marcado, ● para o namespace único.
+func namespaceTable(t data.Table, s data.Scope) (data.Table, []string) {
+	names := []string{""}
+	if t.Err != nil {
+		return t, names
+	}
+	marked := map[string]bool{}
+	for _, ns := range s.Namespaces {
+		marked[ns] = true
+	}
+	out := data.Table{Kind: "ns", Header: []string{"#", "", "NAME", "STATUS", "AGE"}}
+	allMark := ""
+	if len(s.Namespaces) == 0 && displayNS(s.Namespace) == "ALL" {
+		allMark = "●"
+	}
+	out.Rows = append(out.Rows, data.Row{Kind: "ns", Columns: []string{"0", allMark, "ALL"}})
+	for i, r := range t.Rows {
+		names = append(names, r.Name)
+		num, mark := "", ""
+		if i+1 <= 9 {
+			num = strconv.Itoa(i + 1)
+		}
+		switch {
+		case marked[r.Name]:
+			mark = "✓"
+		case len(s.Namespaces) == 0 && r.Name == s.Namespace:
+			mark = "●"
+		}
+		r.Columns = append([]string{num, mark}, r.Columns...)
+		out.Rows = append(out.Rows, r)
+	}
+	return out, names
+}
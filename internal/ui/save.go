@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ktwins/internal/data"
+)
+
+const saveStamp = "20060102-150405"
+
+// SetSaveDir define onde o modal grava logs e describes ([w]/[W]); vazio é o
+// diretório atual.
+func (d *Dashboard) SetSaveDir(dir string) {
+	dir = strings.TrimSpace(dir)
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, rest)
+		}
+	}
+	d.saveDir = dir
+}
+
+// saveModal grava o conteúdo do modal: o log do container (inteiro, relido da
+// API no recorte atual, não só o que o buffer do modal guarda) ou o texto de
+// describe/alertas/eventos.
+func (d *Dashboard) saveModal() {
+	if src := d.logSource; src != nil {
+		if src.selector != "" {
+			d.saveLogBundle(src)
+			return
+		}
+		t := src.target
+		name := fmt.Sprintf("%s_%s_%s_%s", t.Namespace, t.Pod, t.Container, time.Now().Format(saveStamp))
+		if src.previous {
+			name += ".previous"
+		}
+		opts, stamps := src.rng.options(src.previous), src.stamps
+		d.saveFile(name+".log", func(w io.Writer) error {
+			return data.WriteLogs(context.Background(), src.clientset, t, opts, stamps, w)
+		})
+		return
+	}
+	if d.modalFile == "" {
+		return
+	}
+	if m := d.manifest; m != nil && m.root != nil {
+		text, ext, exportErr := m.export()
+		d.saveFile(d.modalFile+"_"+time.Now().Format(saveStamp)+ext, func(w io.Writer) error {
+			if exportErr != nil {
+				return exportErr
+			}
+			_, err := io.WriteString(w, text)
+			return err
+		})
+		return
+	}
+	text := d.modalLogs.GetText(true)
+	d.saveFile(d.modalFile+"_"+time.Now().Format(saveStamp)+".txt", func(w io.Writer) error {
+		_, err := io.WriteString(w, text)
+		return err
+	})
+}
+
+// fileNS é o namespace no nome do arquivo; objetos sem namespace (ou ALL)
+// ficam como "cluster".
+func fileNS(ns string) string {
+	if ns == "" || ns == "ALL" {
+		return "cluster"
+	}
+	return strings.ReplaceAll(ns, ",", "+")
+}
+
+// saveLogBundle junta num tar.gz os logs de todos os containers do pod (ou de
+// todos os pods do workload), com as instâncias anteriores.
+func (d *Dashboard) saveLogBundle(src *logSource) {
+	ns := src.target.Namespace
+	opts, stamps := src.rng.options(false), src.stamps
+	name := fmt.Sprintf("%s_%s_%s.tar.gz", ns, src.target.Pod, time.Now().Format(saveStamp))
+	var targets []data.LogTarget
+	for _, c := range src.containers {
+		targets = append(targets, data.LogTarget{Namespace: ns, Pod: src.target.Pod, Container: c.Name})
+	}
+	if src.selector != "" {
+		name = fmt.Sprintf("%s_%s_%s.tar.gz", ns, strings.ReplaceAll(src.owner, "/", "-"), time.Now().Format(saveStamp))
+	}
+	d.saveFile(name, func(w io.Writer) error {
+		ctx := context.Background()
+		if src.selector != "" {
+			var err error
+			if targets, _, err = data.SelectorTargets(ctx, src.clientset, ns, src.selector); err != nil {
+				return err
+			}
+		}
+		return data.WriteLogBundle(ctx, src.clientset, targets, opts, stamps, w)
+	})
+}
+
+// saveFile grava name em d.saveDir em segundo plano, via arquivo temporário +
+// rename, e avisa no popup de info.
+func (d *Dashboard) saveFile(name string, write func(io.Writer) error) {
+	dir := d.saveDir
+	if dir == "" {
+		dir = "."
+	}
+	path := filepath.Join(dir, name)
+	go d.showInfo("Salvando " + path + "...")
+	go func() {
+		err := func() error {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			tmp := path + ".part"
+			f, err := os.Create(tmp)
+			if err != nil {
+				return err
+			}
+			if err := write(f); err != nil {
+				f.Close()
+				os.Remove(tmp)
+				return err
+			}
+			if err := f.Close(); err != nil {
+				os.Remove(tmp)
+				return err
+			}
+			return os.Rename(tmp, path)
+		}()
+		if err != nil {
+			d.showInfo(fmt.Sprintf("Erro ao salvar %s: %v", path, err))
+			return
+		}
+		d.showInfo("Salvo em " + path)
+	}()
+}
@@ -0,0 +1,86 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// terminal é o tty do usuário em modo raw durante um exec, com o tamanho da
+// janela repassado a cada SIGWINCH.
+type terminal struct {
+	tty   *os.File
+	state *term.State
+	sizes *sizeQueue
+	winch chan os.Signal
+}
+
+// openTerminal abre /dev/tty à parte do os.Stdin: por estar no poller do
+// runtime, a leitura que o exec deixa pendurada é solta por close.
+func openTerminal() (*terminal, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	t := &terminal{tty: tty, sizes: newSizeQueue(), winch: make(chan os.Signal, 1)}
+	if err := t.control(func(fd int) (err error) {
+		t.state, err = term.MakeRaw(fd)
+		return err
+	}); err != nil {
+		tty.Close()
+		return nil, err
+	}
+	t.resize()
+	signal.Notify(t.winch, syscall.SIGWINCH)
+	go func() {
+		for range t.winch {
+			t.resize()
+		}
+	}()
+	return t, nil
+}
+
+// control roda fn com o descritor sem passar por Fd(), que tiraria o
+// arquivo do modo não bloqueante.
+func (t *terminal) control(fn func(fd int) error) error {
+	rc, err := t.tty.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fnErr error
+	if err := rc.Control(func(fd uintptr) { fnErr = fn(int(fd)) }); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+func (t *terminal) resize() {
+	_ = t.control(func(fd int) error {
+		w, h, err := term.GetSize(fd)
+		if err == nil {
+			t.sizes.push(w, h)
+		}
+		return err
+	})
+}
+
+func (t *terminal) Read(p []byte) (int, error)  { return t.tty.Read(p) }
+func (t *terminal) Write(p []byte) (int, error) { return t.tty.Write(p) }
+
+func (t *terminal) Sizes() remotecommand.TerminalSizeQueue { return t.sizes }
+
+// close devolve o terminal ao modo normal para o tview retomar.
+func (t *terminal) close() {
+	signal.Stop(t.winch)
+	close(t.winch)
+	t.sizes.close()
+	_ = t.tty.SetReadDeadline(time.Now())
+	_ = t.control(func(fd int) error { return term.Restore(fd, t.state) })
+	t.tty.Close()
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// pollInput é de quanto em quanto tempo Read confere se o exec acabou.
+const pollInput = 100 // ms
+
+// terminal é o console do usuário em modo raw durante um exec. No Windows
+// não há SIGWINCH: o tamanho vai só na abertura.
+//
+// A leitura do console não é cancelável: fechar o handle não solta um
+// ReadFile pendente. Por isso Read só lê quando WaitForSingleObject diz que
+// há entrada e, no meio tempo, confere done; assim a goroutine de stdin do
+// exec sai sem engolir a primeira tecla do tview. Um evento que não é tecla
+// (mouse, foco) ainda acorda a espera e deixa a leitura presa até a próxima
+// tecla, que se perde se o exec acabar nesse intervalo.
+type terminal struct {
+	conin *os.File // CONIN$ aberto à parte do os.Stdin
+	state *term.State
+	sizes *sizeQueue
+	done  chan struct{}
+}
+
+func openTerminal() (*terminal, error) {
+	conin, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	state, err := term.MakeRaw(int(conin.Fd()))
+	if err != nil {
+		conin.Close()
+		return nil, err
+	}
+	t := &terminal{conin: conin, state: state, sizes: newSizeQueue(), done: make(chan struct{})}
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		t.sizes.push(w, h)
+	}
+	return t, nil
+}
+
+func (t *terminal) Read(p []byte) (int, error) {
+	for {
+		select {
+		case <-t.done:
+			return 0, io.EOF
+		default:
+		}
+		ev, err := windows.WaitForSingleObject(windows.Handle(t.conin.Fd()), pollInput)
+		if err != nil {
+			return 0, err
+		}
+		if ev == windows.WAIT_OBJECT_0 {
+			return t.conin.Read(p)
+		}
+	}
+}
+
+func (t *terminal) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+func (t *terminal) Sizes() remotecommand.TerminalSizeQueue { return t.sizes }
+
+// close devolve o console ao modo normal; o Read pendente vê done e sai.
+func (t *terminal) close() {
+	close(t.done)
+	t.sizes.close()
+	_ = term.Restore(int(t.conin.Fd()), t.state)
+	t.conin.Close()
+}
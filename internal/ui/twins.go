@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"ktwins/internal/data"
+	"ktwins/internal/kube"
+	"ktwins/internal/theme"
+)
+
+// twin é o lado direito do modo twins: outro namespace do mesmo cluster ou
+// outro contexto do kubeconfig, com pipeline de dados próprio.
+type twin struct {
+	scope    data.Scope    // namespace(s) do lado direito; os seletores seguem o dashboard
+	followNS bool          // sem namespace explícito: acompanha o do dashboard
+	session  *kube.Session // nil = mesmo contexto do dashboard
+	backend  data.Backend  // nil = backend do dashboard
+}
+
+const twinsUsage = "uso: :twins <namespace> [--context <ctx>] | :twins off"
+
+// Twin liga o modo twins contra namespace (vazio = o mesmo do dashboard) em
+// kubeContext (vazio = o contexto atual) e abre a página TWINS.
+func (d *Dashboard) Twin(namespace, kubeContext string) error {
+	namespace = strings.TrimSpace(namespace)
+	kubeContext = strings.TrimSpace(kubeContext)
+	if namespace == "" && kubeContext == "" {
+		return errors.New(twinsUsage)
+	}
+	t := &twin{scope: data.Scope{Namespace: namespace}, followNS: namespace == ""}
+	if kubeContext != "" && kubeContext != d.session.Name {
+		session, err := d.kubeconfig.Connect(kubeContext)
+		if err != nil {
+			return err
+		}
+		t.session = session
+		t.backend = d.newBackend(session)
+		if w, ok := t.backend.(data.Watcher); ok {
+			w.Start(func(string) { d.scheduleUpdate() })
+		}
+	}
+
+	d.updateMu.Lock()
+	old := d.twin
+	d.twin = t
+	d.updateMu.Unlock()
+	stopTwin(old)
+
+	d.root.ResizeItem(d.header, headerHeight+1, 0)
+	d.twinLeft.setText("Carregando...")
+	d.twinRight.setText("Carregando...")
+	d.setPage("twins")
+	d.scheduleUpdate()
+	return nil
+}
+
+// clearTwin desliga o modo twins e o pipeline do lado direito.
+func (d *Dashboard) clearTwin() {
+	d.updateMu.Lock()
+	old := d.twin
+	d.twin = nil
+	d.updateMu.Unlock()
+	stopTwin(old)
+	d.root.ResizeItem(d.header, headerHeight, 0)
+	d.exitBrowse()
+	d.scheduleUpdate()
+}
+
+func stopTwin(t *twin) {
+	if t == nil {
+		return
+	}
+	if w, ok := t.backend.(data.Watcher); ok {
+		go w.Stop()
+	}
+}
+
+// twinCommand trata ":twins <namespace> [--context <ctx>]" e ":twins off";
+// sem argumentos só abre a página.
+func (d *Dashboard) twinCommand(args []string) {
+	if len(args) == 1 && args[0] == "off" {
+		d.clearTwin()
+		go d.showInfo("Modo twins desligado")
+		return
+	}
+	if len(args) > 0 {
+		namespace, kubeContext, err := parseTwinArgs(args)
+		if err == nil {
+			err = d.Twin(namespace, kubeContext)
+		}
+		if err != nil {
+			go d.showInfo(fmt.Sprintf("Twins: %v", err))
+		}
+		return
+	}
+	d.setPage("twins")
+}
+
+func parseTwinArgs(args []string) (namespace, kubeContext string, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--context" || arg == "-c":
+			if i+1 >= len(args) {
+				return "", "", errors.New(twinsUsage)
+			}
+			i++
+			kubeContext = args[i]
+		case strings.HasPrefix(arg, "--context="):
+			kubeContext = strings.TrimPrefix(arg, "--context=")
+		case namespace == "" && !strings.HasPrefix(arg, "-"):
+			namespace = arg
+		default:
+			return "", "", errors.New(twinsUsage)
+		}
+	}
+	return namespace, kubeContext, nil
+}
+
+// twinSides monta os dois lados para o escopo atual do dashboard; chamado
+// com updateMu travado.
+func (d *Dashboard) twinSides(scope data.Scope) (left, right data.TwinSide) {
+	left = data.TwinSide{Label: sideLabel(d.session, scope), Scope: scope, Backend: d.backend}
+
+	rs := d.twin.scope
+	if d.twin.followNS {
+		rs.Namespace, rs.Namespaces = scope.Namespace, scope.Namespaces
+	}
+	rs.Labels, rs.Fields = scope.Labels, scope.Fields
+	session, backend := d.session, d.backend
+	if d.twin.session != nil {
+		session, backend = d.twin.session, d.twin.backend
+	}
+	right = data.TwinSide{Label: sideLabel(session, rs), Scope: rs, Backend: backend}
+	return left, right
+}
+
+func sideLabel(s *kube.Session, scope data.Scope) string {
+	ns := displayNS(scope.Namespace)
+	if len(scope.Namespaces) > 0 {
+		ns = strings.Join(scope.Namespaces, ",")
+	}
+	return s.Name + "/" + ns
+}
+
+// selectionSession é a sessão de onde vem o objeto selecionado: a do lado
+// direito do modo twins ou a do dashboard.
+func (d *Dashboard) selectionSession() *kube.Session {
+	if d.twin != nil && d.twin.session != nil && d.browseBox == d.twinRight {
+		return d.twin.session
+	}
+	return d.session
+}
+
+// selectionBackend é o backend de onde vem o objeto selecionado.
+func (d *Dashboard) selectionBackend() data.Backend {
+	if s := d.selectionSession(); s != d.session {
+		return d.twin.backend
+	}
+	return d.backend
+}
+
+// twinContextArgs aponta o kubectl para o contexto do lado direito quando o
+// objeto selecionado vem dele.
+func (d *Dashboard) twinContextArgs() []string {
+	if s := d.selectionSession(); s != d.session {
+		return []string{"--context", s.Name}
+	}
+	return nil
+}
+
+func formatTwinSummary(r data.TwinReport, left, right string) string {
+	return fmt.Sprintf("%stwins%s %s ⇄ %s  same:%d %sdiff:%d%s %sonly-left:%d only-right:%d%s",
+		theme.Header, theme.Reset, tview.Escape(left), tview.Escape(right),
+		r.Counts[data.TwinSame],
+		theme.Yellow, r.Counts[data.TwinChanged], theme.Reset,
+		theme.Red, r.Counts[data.TwinOnlyLeft], r.Counts[data.TwinOnlyRight], theme.Reset)
+}
+
+// formatTwinDiffs lista, por objeto, o que muda de um lado para o outro.
+func formatTwinDiffs(r data.TwinReport, left, right string) string {
+	if len(r.Diffs) == 0 {
+		return fmt.Sprintf("%sSem diferenças entre %s e %s.%s", theme.Green, tview.Escape(left), tview.Escape(right), theme.Reset)
+	}
+	var b strings.Builder
+	for _, diff := range r.Diffs {
+		name := tview.Escape(diff.Kind + "/" + diff.Name)
+		switch diff.State {
+		case data.TwinOnlyLeft:
+			fmt.Fprintf(&b, "%s%s%s  só em %s\n", theme.Red, name, theme.Reset, tview.Escape(left))
+		case data.TwinOnlyRight:
+			fmt.Fprintf(&b, "%s%s%s  só em %s\n", theme.Red, name, theme.Reset, tview.Escape(right))
+		default:
+			fmt.Fprintf(&b, "%s%s%s  %s\n", theme.Yellow, name, theme.Reset, tview.Escape(strings.Join(diff.Changes, " · ")))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func newTwinViews() (left, right *listView, diff *tview.TextView) {
+	left = newListView("TWINS", true)
+	right = newListView("TWINS", true)
+	left.mirror, right.mirror = right, left
+	diff = newBox("DIFF")
+	diff.SetWrap(true)
+	diff.SetBorderColor(tcell.ColorWheat)
+	return left, right, diff
+}
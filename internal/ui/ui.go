@@ -4,21 +4,36 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
-	"k8s.io/client-go/kubernetes"
+	"ktwins/internal/config"
 	"ktwins/internal/data"
+	"ktwins/internal/kube"
 	"ktwins/internal/theme"
 )
 
+// panel é o que TextView e listView têm em comum para borda e título.
+type panel interface {
+	tview.Primitive
+	SetBorder(show bool) *tview.Box
+	SetBorderColor(color tcell.Color) *tview.Box
+	SetTitle(title string) *tview.Box
+	GetTitle() string
+}
+
 // Dashboard encapsula estado e handlers da UI.
 type Dashboard struct {
-	ns        string
-	clientset *kubernetes.Clientset
+	scope      data.Scope
+	session    *kube.Session
+	kubeconfig *kube.Kubeconfig
+	newBackend func(*kube.Session) data.Backend // recriado a cada troca de contexto
+	backend    data.Backend
+	cfg        *config.Config
 
 	app *tview.Application
 
@@ -27,19 +42,23 @@ type Dashboard struct {
 	overview       *tview.TextView
 	alertsView     *tview.TextView
 	eventsView     *tview.TextView
-	namespacesView *tview.TextView
-	infraView      *tview.TextView
-	configView     *tview.TextView
-	storageView    *tview.TextView
-	networkView    *tview.TextView
-	workloadsView  *tview.TextView
-	podsView       *tview.TextView
-	metricsView    *tview.TextView
+	namespacesView *listView
+	infraView      *listView
+	configView     *listView
+	storageView    *listView
+	networkView    *listView
+	workloadsView  *listView
+	podsView       *listView
+	metricsView    *listView
+	twinLeft       *listView
+	twinRight      *listView
+	twinDiff       *tview.TextView
 
 	workloadsPage *tview.Flex
 	clusterPage   *tview.Flex
 	networkPage   *tview.Flex
 	metricsPage   *tview.Flex
+	twinsPage     *tview.Flex
 	pages         *tview.Pages
 	pageIndicator *tview.TextView
 	header        *tview.Flex
@@ -49,47 +68,84 @@ type Dashboard struct {
 	pageOrder   []string
 
 	contentCache   map[*tview.TextView]string
-	browseBox      *tview.TextView
-	selectedLine   int
+	browseBox      *listView
 	modalOpen      bool
+	logStream      *logStream // stream do modal de logs aberto
+	logSource      *logSource
+	manifest       *manifestView       // modal [y] aberto
+	edit           *editSession        // diff do [E] à espera de confirmação
+	forwards       []*data.PortForward // port-forwards abertos com [f]; caem no [q]
+	forwardSeq     int                 // último PortForward.ID atribuído
+	modalFile      string              // nome base para gravar o modal com [w]; vazio = nada a gravar
+	saveDir        string
+	promptOpen     bool
+	pickerOpen     bool
+	confirmOpen    bool
+	panelOpen      bool // painel modal (history, port-forwards) com as teclas para si
 	restoreFocus   tview.Primitive
 	nsList         []string
+	nsTable        data.Table
 	updateMu       sync.Mutex
-	borderDefaults map[*tview.TextView]tcell.Color
-	originalTitles map[*tview.TextView]string
+	borderDefaults map[panel]tcell.Color
+	baseTitles     map[panel]string
+	boxKinds       map[panel][]string
+	titleCounts    map[panel]string
+	twin           *twin
 	updateCh       chan struct{}
 	ticker         *time.Ticker
 }
 
-func NewDashboard(ns string, clientset *kubernetes.Clientset) *Dashboard {
+const (
+	pollInterval      = 2 * time.Second        // backends sem watch
+	watchRefresh      = 10 * time.Second       // idades, métricas e CRDs com informers
+	minRedrawInterval = 500 * time.Millisecond // agrupa rajadas de eventos do watch
+	eventsShown       = 20                     // mais recentes no box EVENTS; o popup [e] mostra todos
+	headerHeight      = 9                      // OVERVIEW com 7 linhas; o modo twins acrescenta uma
+)
+
+func NewDashboard(scope data.Scope, session *kube.Session, kubeconfig *kube.Kubeconfig, newBackend func(*kube.Session) data.Backend, cfg *config.Config) *Dashboard {
 	d := &Dashboard{
-		ns:             ns,
-		clientset:      clientset,
+		scope:          scope,
+		session:        session,
+		kubeconfig:     kubeconfig,
+		newBackend:     newBackend,
+		backend:        newBackend(session),
+		cfg:            cfg,
 		app:            tview.NewApplication(),
 		modalLogs:      newTextArea("LOGS"),
 		infoPopup:      newTextArea("INFO"),
 		overview:       newBox("OVERVIEW"),
 		alertsView:     newBox("ALERTS"),
 		eventsView:     newBox("EVENTS"),
-		namespacesView: newBox("NAMESPACES"),
-		infraView:      newBox("INFRA"),
-		configView:     newBox("CONFIG"),
-		storageView:    newBox("STORAGE"),
-		networkView:    newBox("NETWORK"),
-		workloadsView:  newBox("WORKLOADS"),
-		podsView:       newBox("PODS"),
-		metricsView:    newBox("POD METRICS"),
+		namespacesView: newListView("NAMESPACES", false),
+		infraView:      newListView("INFRA", true),
+		configView:     newListView("CONFIG", true),
+		storageView:    newListView("STORAGE", true),
+		networkView:    newListView("NETWORK", true),
+		workloadsView:  newListView("WORKLOADS", true),
+		podsView:       newListView("PODS", false),
+		metricsView:    newListView("POD METRICS", false),
 		pageIndicator:  tview.NewTextView().SetDynamicColors(true).SetWrap(false),
 		contentCache:   map[*tview.TextView]string{},
-		originalTitles: map[*tview.TextView]string{},
+		baseTitles:     map[panel]string{},
+		titleCounts:    map[panel]string{},
 		updateCh:       make(chan struct{}, 1),
 		currentPage:    "workloads",
-		pageOrder:      []string{"workloads", "network", "cluster", "metrics"},
-		selectedLine:   0,
+		pageOrder:      []string{"workloads", "network", "cluster", "metrics", "twins"},
 	}
+	d.twinLeft, d.twinRight, d.twinDiff = newTwinViews()
 
 	d.modalLogs.SetTitle("LOGS")
+	d.modalLogs.SetMouseCapture(func(action tview.MouseAction, ev *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseScrollUp && d.logStream != nil {
+			d.logStream.setFollow(false)
+		}
+		return action, ev
+	})
 	d.infoPopup.SetTitle("INFO")
+	data.SetKubectlContext(session.Name)
+	d.refreshContextTitle()
+	d.metricsView.color = tcell.ColorGreen
 
 	alertEventColumn := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(d.alertsView, 0, 1, false).
@@ -116,19 +172,40 @@ func NewDashboard(ns string, clientset *kubernetes.Clientset) *Dashboard {
 	d.metricsPage = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(d.metricsView, 0, 1, false)
 
+	d.twinsPage = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(d.twinLeft, 0, 1, false).
+			AddItem(d.twinRight, 0, 1, false), 0, 3, false).
+		AddItem(d.twinDiff, 0, 1, false)
+
 	d.pages = tview.NewPages().
 		AddPage("workloads", d.workloadsPage, true, true).
 		AddPage("network", d.networkPage, true, false).
 		AddPage("cluster", d.clusterPage, true, false).
-		AddPage("metrics", d.metricsPage, true, false)
+		AddPage("metrics", d.metricsPage, true, false).
+		AddPage("twins", d.twinsPage, true, false)
 
 	d.root = tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(header, 9, 0, false).
+		AddItem(header, headerHeight, 0, false).
 		AddItem(d.pages, 0, 1, true).
 		AddItem(d.pageIndicator, 1, 0, false)
 
+	d.boxKinds = map[panel][]string{
+		d.namespacesView: {"ns"},
+		d.eventsView:     {"events"},
+		d.infraView:      {"nodes"},
+		d.configView:     {"secrets", "configmaps", "serviceaccounts"},
+		d.storageView:    {"pvc", "pv"},
+		d.networkView:    {"svc", "ingress", "endpoints"},
+		d.workloadsView:  {"deploy", "rs", "sts", "ds", "jobs", "cronjobs"},
+		d.podsView:       {"pods"},
+	}
+	for _, box := range d.titledPanels() {
+		d.baseTitles[box] = box.GetTitle()
+	}
+
 	d.setPlaceholders()
-	d.borderDefaults = map[*tview.TextView]tcell.Color{
+	d.borderDefaults = map[panel]tcell.Color{
 		d.namespacesView: tcell.ColorWhite,
 		d.overview:       tcell.ColorLightSkyBlue,
 		d.alertsView:     tcell.ColorGreen,
@@ -140,6 +217,8 @@ func NewDashboard(ns string, clientset *kubernetes.Clientset) *Dashboard {
 		d.workloadsView:  tcell.ColorPurple,
 		d.podsView:       tcell.ColorPurple,
 		d.metricsView:    tcell.ColorPurple,
+		d.twinLeft:       tcell.ColorPurple,
+		d.twinRight:      tcell.ColorPurple,
 	}
 
 	return d
@@ -149,53 +228,77 @@ func (d *Dashboard) setPlaceholders() {
 	d.overview.SetText("Carregando...")
 	d.alertsView.SetText("Carregando...")
 	d.eventsView.SetText("Carregando...")
-	d.workloadsView.SetText("Carregando...")
-	d.podsView.SetText("Carregando...")
-	d.infraView.SetText("Carregando...")
-	d.configView.SetText("Carregando...")
-	d.networkView.SetText("Carregando...")
-	d.storageView.SetText("Carregando...")
-	d.metricsView.SetText("Carregando...")
+	d.twinDiff.SetText("")
+	for _, box := range d.allBoxes() {
+		box.setText("Carregando...")
+	}
 }
 
-func (d *Dashboard) allBoxes() []*tview.TextView {
-	return []*tview.TextView{d.workloadsView, d.podsView, d.infraView, d.configView, d.storageView, d.networkView, d.metricsView}
+// titledPanels são os boxes cujo título é recomposto a cada refresh (sync, contagens, sort, filtro).
+func (d *Dashboard) titledPanels() []panel {
+	panels := []panel{d.alertsView, d.eventsView}
+	for _, box := range d.allBoxes() {
+		panels = append(panels, box)
+	}
+	return panels
 }
 
-func (d *Dashboard) focusOrder() []*tview.TextView {
-	base := []*tview.TextView{}
+func (d *Dashboard) allBoxes() []*listView {
+	return []*listView{d.workloadsView, d.podsView, d.infraView, d.configView, d.storageView, d.networkView, d.metricsView, d.twinLeft, d.twinRight, d.namespacesView}
+}
+
+// listFor mapeia o foco do app para o box; cliques do mouse focam a Table interna.
+func (d *Dashboard) listFor(p tview.Primitive) *listView {
+	for _, box := range d.allBoxes() {
+		if p == box || p == box.Table {
+			return box
+		}
+	}
+	return nil
+}
+
+func (d *Dashboard) focusOrder() []*listView {
+	base := []*listView{}
 	switch d.currentPage {
 	case "workloads":
-		if strings.TrimSpace(d.contentCache[d.workloadsView]) != "" {
+		if d.workloadsView.hasContent() {
 			base = append(base, d.workloadsView)
 		}
-		if strings.TrimSpace(d.contentCache[d.podsView]) != "" {
+		if d.podsView.hasContent() {
 			base = append(base, d.podsView)
 		}
 	case "network":
-		if strings.TrimSpace(d.contentCache[d.networkView]) != "" {
+		if d.networkView.hasContent() {
 			base = append(base, d.networkView)
 		}
 	case "cluster":
-		if strings.TrimSpace(d.contentCache[d.infraView]) != "" {
+		if d.infraView.hasContent() {
 			base = append(base, d.infraView)
 		}
-		if strings.TrimSpace(d.contentCache[d.configView]) != "" {
+		if d.configView.hasContent() {
 			base = append(base, d.configView)
 		}
-		if strings.TrimSpace(d.contentCache[d.storageView]) != "" {
+		if d.storageView.hasContent() {
 			base = append(base, d.storageView)
 		}
 	case "metrics":
-		if strings.TrimSpace(d.contentCache[d.metricsView]) != "" {
+		if d.metricsView.hasContent() {
 			base = append(base, d.metricsView)
 		}
+	case "twins":
+		if d.twinLeft.hasContent() {
+			base = append(base, d.twinLeft, d.twinRight)
+		}
+	}
+	// NAMESPACES fica no cabeçalho, acessível de qualquer página
+	if d.namespacesView.hasContent() {
+		base = append(base, d.namespacesView)
 	}
 	return base
 }
 
 func (d *Dashboard) highlightFocus() {
-	focused := d.app.GetFocus()
+	focused := d.listFor(d.app.GetFocus())
 	for _, b := range d.allBoxes() {
 		if b == focused {
 			b.SetBorderColor(tcell.ColorOrange)
@@ -216,75 +319,150 @@ func (d *Dashboard) highlightFocus() {
 }
 
 func (d *Dashboard) buildIndicator(page string) string {
-	return fmt.Sprintf("%s%s%s%s | %s%s%s%s | %s%s%s%s | %s%s%s%s | %s%s%s%s | %s%s%s%s | %s%s%s%s | %s%s%s%s",
-		theme.ColorFor(page == "workloads"), tview.Escape("[w]"), theme.Reset, "orkloads",
-		theme.ColorFor(page == "network"), tview.Escape("[n]"), theme.Reset, "etwork",
-		theme.ColorFor(page == "cluster"), tview.Escape("[c]"), theme.Reset, "luster",
-		theme.ColorFor(page == "metrics"), tview.Escape("[m]"), theme.Reset, "etrics",
-		theme.Header, tview.Escape("[a]"), theme.Reset, "lerts",
-		theme.Header, tview.Escape("[e]"), theme.Reset, "vents",
-		theme.Header, tview.Escape("[0-9]"), theme.Reset, " namespace",
-		theme.Header, tview.Escape("[q]"), theme.Reset, "uit")
-}
-
-func (d *Dashboard) applyBrowseStyle(box *tview.TextView) {
-	d.originalTitles[box] = box.GetTitle()
-	box.SetBorderColor(tcell.ColorGreen)
-	box.SetTitle(tview.Escape(fmt.Sprintf("%s [L]ogs / [D]escribe", strings.TrimSpace(d.originalTitles[box]))))
+	keys := []struct{ color, key, rest string }{
+		{theme.ColorFor(page == "workloads"), "[w]", "orkloads"},
+		{theme.ColorFor(page == "network"), "[n]", "etwork"},
+		{theme.ColorFor(page == "cluster"), "[c]", "luster"},
+		{theme.ColorFor(page == "metrics"), "[m]", "etrics"},
+		{theme.ColorFor(page == "twins"), "[t]", "wins"},
+		{theme.Header, "[a]", "lerts"},
+		{theme.Header, "[e]", "vents"},
+		{theme.Header, "[0-9/^N]", " namespace"},
+		{theme.Header, "[^K]", " context"},
+		{theme.Header, "[F]", " forwards"},
+		{theme.Header, "[q]", "uit"},
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.color + tview.Escape(k.key) + theme.Reset + k.rest
+	}
+	return strings.Join(parts, " | ")
 }
 
-func (d *Dashboard) restoreBrowseStyle(box *tview.TextView) {
-	if raw, ok := d.contentCache[box]; ok {
-		box.SetText(raw)
+// syncLabel resume, por kind do box, há quanto tempo chegou o último evento do watch.
+func (d *Dashboard) syncLabel(box panel) string {
+	w, ok := d.backend.(data.Watcher)
+	if !ok {
+		return ""
 	}
-	if title, ok := d.originalTitles[box]; ok {
-		box.SetTitle(title)
+	kinds := d.boxKinds[box]
+	var parts []string
+	for _, kind := range kinds {
+		st := w.SyncState(kind)
+		if !st.Watched {
+			continue
+		}
+		var label string
+		switch {
+		case !st.Synced || st.At.IsZero():
+			label = "..."
+		case st.Err != nil:
+			label = "!" + shortDuration(time.Since(st.At))
+		default:
+			label = shortDuration(time.Since(st.At))
+		}
+		if len(kinds) > 1 {
+			label = kind + " " + label
+		}
+		parts = append(parts, label)
 	}
-	if def, ok := d.borderDefaults[box]; ok {
-		box.SetBorderColor(def)
+	if len(parts) == 0 {
+		return ""
 	}
+	return "sync " + strings.Join(parts, " · ")
 }
 
-func (d *Dashboard) openLogsSelected() {
-	if d.browseBox == nil || d.browseBox != d.podsView {
+func (d *Dashboard) refreshTitle(box panel) {
+	base, ok := d.baseTitles[box]
+	if !ok {
 		return
 	}
-	raw := d.contentCache[d.browseBox]
-	lines := strings.Split(raw, "\n")
-	if d.selectedLine < 0 || d.selectedLine >= len(lines) {
-		return
+	title := base
+	if count := d.titleCounts[box]; count != "" {
+		title += " (" + count + ")"
 	}
-	nsTarget := d.resourceNSFor(d.browseBox, lines[d.selectedLine], d.ns)
-	name := d.resourceNameFor(d.browseBox, lines[d.selectedLine], d.ns)
-	if name != "" {
-		d.openLogs(name, nsTarget)
+	if lv, ok := box.(*listView); ok {
+		if sort := lv.sortLabel(); sort != "" {
+			title += " [" + sort + "]"
+		}
+		if filter := lv.filterLabel(); filter != "" {
+			title += " [" + filter + "]"
+		}
+	}
+	if sync := d.syncLabel(box); sync != "" {
+		title += " (" + sync + ")"
 	}
+	switch {
+	case d.browseBox == nil || box != panel(d.browseBox):
+	case d.browseBox == d.namespacesView:
+		title += " [Space] mark / [Enter] use"
+	default:
+		title += " " + d.rowKeys(box)
+	}
+	box.SetTitle(tview.Escape(title))
 }
 
-func (d *Dashboard) openDescribeSelected() {
+// rowKeys são as teclas da linha selecionada que valem no box; o rodapé
+// fica só com a navegação para caber em terminais estreitos.
+func (d *Dashboard) rowKeys(box panel) string {
+	keys := []string{"[d]escribe", "[y]aml", "[E]dit", "[x] actions"}
+	switch box {
+	case panel(d.podsView):
+		keys = append([]string{"[l]ogs"}, append(keys, "[s]hell", "[f]orward")...)
+	case panel(d.workloadsView):
+		keys = append([]string{"[l]ogs"}, keys...)
+	case panel(d.networkView):
+		keys = append([]string{"[l]ogs"}, append(keys, "[f]orward")...)
+	}
+	return strings.Join(append(keys, "[D]elete"), " / ")
+}
+
+func (d *Dashboard) applyBrowseStyle(box *listView) {
+	box.SetBorderColor(tcell.ColorGreen)
+	d.refreshTitle(box)
+}
+
+func (d *Dashboard) restoreBrowseStyle(box *listView) {
+	d.refreshTitle(box)
+	if def, ok := d.borderDefaults[box]; ok {
+		box.SetBorderColor(def)
+	}
+}
+
+// selectedRow devolve o objeto sob a seleção do box em browse.
+func (d *Dashboard) selectedRow() *data.Row {
 	if d.browseBox == nil {
-		return
+		return nil
 	}
-	raw := d.contentCache[d.browseBox]
-	lines := strings.Split(raw, "\n")
-	if d.selectedLine < 0 || d.selectedLine >= len(lines) {
+	return d.browseBox.selectedRow()
+}
+
+func (d *Dashboard) openLogsSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
 		return
 	}
-	kind := d.resourceKindFor(d.browseBox, lines, d.selectedLine)
-	if kind == "" {
-		return
+	switch {
+	case row.Kind == "pods":
+		d.openLogs(row.Name, row.Namespace)
+	case data.LogKinds[row.Kind]:
+		d.openWorkloadLogs(row.Kind, row.Name, row.Namespace)
 	}
-	nsTarget := d.resourceNSFor(d.browseBox, lines[d.selectedLine], d.ns)
-	name := d.resourceNameFor(d.browseBox, lines[d.selectedLine], d.ns)
-	if name != "" {
-		d.openDescribe(kind, name, nsTarget)
+}
+
+func (d *Dashboard) openDescribeSelected() {
+	row := d.selectedRow()
+	if row == nil || row.Status == data.TwinAbsent {
+		return
 	}
+	d.openDescribe(row.Kind, row.Name, row.Namespace)
 }
 
 func (d *Dashboard) setPage(page string) {
 	d.exitBrowse()
 	d.currentPage = page
 	d.pages.SwitchToPage(page)
+	d.watchShown()
 	d.pageIndicator.SetText(d.buildIndicator(page))
 	if items := d.focusOrder(); len(items) > 0 {
 		d.app.SetFocus(items[0])
@@ -297,7 +475,7 @@ func (d *Dashboard) moveFocus(delta int) {
 	if len(list) == 0 {
 		return
 	}
-	cur := d.app.GetFocus()
+	cur := d.listFor(d.app.GetFocus())
 	idx := -1
 	for i, v := range list {
 		if v == cur {
@@ -315,64 +493,25 @@ func (d *Dashboard) moveFocus(delta int) {
 	d.highlightFocus()
 }
 
-func (d *Dashboard) highlightBox(box *tview.TextView) {
-	raw, ok := d.contentCache[box]
-	if !ok || raw == "" {
-		return
-	}
-	lines := strings.Split(raw, "\n")
-	if len(lines) == 0 {
+func (d *Dashboard) enterBrowse(box *listView) {
+	if box == nil || box == d.metricsView {
 		return
 	}
-	if !d.isSelectable(box, lines, d.selectedLine) {
-		d.selectedLine = d.findSelectable(box, lines, d.selectedLine, 1, true)
-	}
-	if d.selectedLine == -1 {
-		box.SetText(raw)
-		return
-	}
-	for i, l := range lines {
-		if i == d.selectedLine {
-			lines[i] = fmt.Sprintf("[black:yellow]%s[-:-:-]", l)
-		}
-	}
-	box.SetText(strings.Join(lines, "\n"))
-	if d.selectedLine >= 0 {
-		_, currentTop := box.GetScrollOffset()
-		_, _, _, h := box.GetInnerRect()
-		if h <= 0 {
-			return
-		}
-		if d.selectedLine < currentTop {
-			box.ScrollTo(d.selectedLine, 0)
-		} else if d.selectedLine >= currentTop+h {
-			box.ScrollTo(d.selectedLine-h+1, 0)
-		}
-	}
-}
-
-func (d *Dashboard) enterBrowse(box *tview.TextView) {
-	if box != d.podsView && box != d.workloadsView && box != d.configView && box != d.networkView && box != d.storageView && box != d.infraView {
-		return
-	}
-	raw := d.contentCache[box]
-	lines := strings.Split(raw, "\n")
-	start := d.findSelectable(box, lines, 1, 1, true)
-	if start == -1 {
+	if !box.startBrowse() {
 		return
 	}
 	d.browseBox = box
-	d.selectedLine = start
 	d.applyBrowseStyle(box)
 	d.app.SetFocus(box)
-	d.highlightBox(box)
 }
 
 func (d *Dashboard) exitBrowse() {
-	if d.browseBox != nil {
-		d.restoreBrowseStyle(d.browseBox)
-	}
+	box := d.browseBox
 	d.browseBox = nil
+	if box != nil {
+		box.stopBrowse()
+		d.restoreBrowseStyle(box)
+	}
 }
 
 func (d *Dashboard) switchPage(delta int) {
@@ -387,38 +526,20 @@ func (d *Dashboard) switchPage(delta int) {
 	d.setPage(d.pageOrder[idx])
 }
 
-func (d *Dashboard) openLogs(name, targetNS string) {
-	if name == "" {
-		return
-	}
-	d.openModal("LOGS "+name, "Carregando logs...")
-
-	go func() {
-		nsUse := d.ns
-		if strings.TrimSpace(targetNS) != "" {
-			nsUse = targetNS
-		}
-		args := []string{"logs", name, "--tail=200"}
-		args = append(args, data.NSSelector(nsUse, false)...)
-		logs := data.RunKubectl(args...)
-		_ = d.app.QueueUpdateDraw(func() {
-			d.modalLogs.SetText(logs)
-		})
-	}()
-}
-
 func (d *Dashboard) openDescribe(kind, name, targetNS string) {
 	if name == "" || kind == "" {
 		return
 	}
+	nsUse := d.scope.Namespace
+	if strings.TrimSpace(targetNS) != "" {
+		nsUse = targetNS
+	}
+	d.modalFile = fmt.Sprintf("%s_%s_%s", fileNS(nsUse), kind, name)
 	d.openModal(fmt.Sprintf("DESCRIBE %s/%s", kind, name), "Carregando describe...")
+	ctxArgs := d.twinContextArgs()
 
 	go func() {
-		nsUse := d.ns
-		if strings.TrimSpace(targetNS) != "" {
-			nsUse = targetNS
-		}
-		args := []string{"describe", kind, name}
+		args := append(ctxArgs, "describe", kind, name)
 		args = append(args, data.NSSelector(nsUse, false)...)
 		desc := data.RunKubectl(args...)
 		_ = d.app.QueueUpdateDraw(func() {
@@ -427,254 +548,166 @@ func (d *Dashboard) openDescribe(kind, name, targetNS string) {
 	}()
 }
 
-func (d *Dashboard) openModal(title, body string) {
-	d.restoreFocus = d.app.GetFocus()
-	d.modalOpen = true
-	d.modalLogs.SetTitle(title + " (Esc fecha)")
-	d.modalLogs.SetText(body)
-	d.pages.AddPage("modalLogs", tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(d.modalLogs, 0, 1, true), true, true)
-}
-
-func (d *Dashboard) showInfo(msg string) {
-	_ = d.app.QueueUpdateDraw(func() {
-		d.pages.RemovePage("infoPopup")
-		d.infoPopup.SetText(msg)
-		d.pages.AddPage("infoPopup", tview.NewFlex().SetDirection(tview.FlexRow).
-			AddItem(nil, 0, 1, false).
-			AddItem(d.infoPopup, 5, 0, true), true, true)
-	})
-	go func() {
-		time.Sleep(1500 * time.Millisecond)
-		_ = d.app.QueueUpdateDraw(func() {
-			d.pages.RemovePage("infoPopup")
-		})
-	}()
+// useNamespace volta a um namespace único ("" = ALL), desfazendo o conjunto marcado.
+func (d *Dashboard) useNamespace(ns string) {
+	old := d.scopeLabel()
+	d.updateMu.Lock()
+	d.scope.Namespace = strings.TrimSpace(ns)
+	d.scope.Namespaces = nil
+	d.updateMu.Unlock()
+	d.rememberNamespace(d.scope.Namespace)
+	d.saveNamespaces()
+	go d.showInfo(fmt.Sprintf("Namespace: %s -> %s", old, d.scopeLabel()))
+	d.exitBrowse()
+	d.scheduleUpdate()
 }
 
-func (d *Dashboard) findSelectable(box *tview.TextView, lines []string, start int, delta int, wrap bool) int {
-	if len(lines) == 0 || delta == 0 {
-		return -1
+// toggleNamespace marca/desmarca ns no conjunto; o namespace único em uso
+// entra no conjunto na primeira marcação, e conjunto vazio volta para ALL.
+func (d *Dashboard) toggleNamespace(ns string) {
+	if ns == "" {
+		d.useNamespace("")
+		return
 	}
-	if wrap {
-		i := start
-		for count := 0; count < len(lines); count++ {
-			if i < 0 {
-				i = len(lines) - 1
-			}
-			if i >= len(lines) {
-				i = 0
-			}
-			if d.isSelectable(box, lines, i) {
-				return i
-			}
-			i += delta
-		}
-		return -1
+	set := map[string]bool{}
+	for _, n := range d.scope.Namespaces {
+		set[n] = true
+	}
+	if len(set) == 0 && displayNS(d.scope.Namespace) != "ALL" {
+		set[d.scope.Namespace] = true
 	}
-	for i := start; i >= 0 && i < len(lines); i += delta {
-		if d.isSelectable(box, lines, i) {
-			return i
+	set[ns] = !set[ns]
+	var names []string
+	for n, on := range set {
+		if on {
+			names = append(names, n)
 		}
 	}
-	return -1
+	sort.Strings(names)
+	d.updateMu.Lock()
+	d.scope.Namespace = ""
+	d.scope.Namespaces = names
+	d.updateMu.Unlock()
+	d.saveNamespaces()
+	go d.showInfo("Namespaces: " + d.scopeLabel())
+	d.scheduleUpdate()
 }
 
-func (d *Dashboard) isSelectable(box *tview.TextView, lines []string, idx int) bool {
-	if idx < 0 || idx >= len(lines) {
-		return false
-	}
-	line := strings.TrimSpace(lines[idx])
-	if line == "" {
-		return false
-	}
-	if strings.ToUpper(line) == line {
-		return false
-	}
-	fields := strings.Fields(line)
-	if len(fields) == 0 {
-		return false
-	}
-	joined := strings.ToLower(strings.Join(fields, " "))
-	if strings.Contains(joined, "no resources found") {
-		return false
-	}
-	if fields[0] == "NAME" {
-		return false
-	}
-	if strings.ToUpper(fields[0]) == fields[0] && len(fields[0]) <= 8 && len(fields) == 1 {
-		return false
-	}
-	switch box {
-	case d.workloadsView:
-		if len(fields) <= 1 {
-			return false
-		}
-		return true
-	case d.podsView:
-		return len(fields) > 1
-	case d.alertsView, d.eventsView:
-		return len(fields) > 0
-	default:
-		return len(fields) > 1
+func (d *Dashboard) scopeLabel() string {
+	if len(d.scope.Namespaces) > 0 {
+		return strings.Join(d.scope.Namespaces, ",")
 	}
+	return displayNS(d.scope.Namespace)
 }
 
-func (d *Dashboard) resourceKindFor(box *tview.TextView, lines []string, idx int) string {
-	if idx < 0 || idx >= len(lines) {
-		return ""
+// saveNamespaces persiste o conjunto marcado (e os recentes) do cluster atual.
+func (d *Dashboard) saveNamespaces() {
+	if d.cfg == nil {
+		return
 	}
-	switch box {
-	case d.podsView, d.alertsView:
-		return "pod"
-	case d.workloadsView:
-		for i := idx; i >= 0; i-- {
-			title := strings.TrimSpace(lines[i])
-			switch title {
-			case "DEPLOY":
-				return "deploy"
-			case "RS":
-				return "rs"
-			case "STS":
-				return "sts"
-			case "DS":
-				return "ds"
-			case "JOBS":
-				return "jobs"
-			case "CRONJOBS":
-				return "cronjobs"
-			}
-		}
-		return ""
-	case d.configView:
-		for i := idx; i >= 0; i-- {
-			title := strings.TrimSpace(lines[i])
-			switch title {
-			case "SECRETS":
-				return "secrets"
-			case "CONFIGMAPS":
-				return "configmaps"
-			case "SERVICEACCOUNTS":
-				return "serviceaccounts"
-			}
-		}
-		return ""
-	case d.networkView:
-		for i := idx; i >= 0; i-- {
-			title := strings.TrimSpace(lines[i])
-			switch title {
-			case "SVC":
-				return "svc"
-			case "INGRESS":
-				return "ingress"
-			case "ENDPOINTS":
-				return "endpoints"
-			}
-		}
-		return ""
-	case d.storageView:
-		for i := idx; i >= 0; i-- {
-			title := strings.TrimSpace(lines[i])
-			switch title {
-			case "PVC":
-				return "pvc"
-			case "PV":
-				return "pv"
-			}
-		}
-		return ""
-	case d.infraView:
-		for i := idx; i >= 0; i-- {
-			title := strings.TrimSpace(lines[i])
-			switch title {
-			case "NODES":
-				return "nodes"
-			case "CRDS":
-				return "crd"
-			}
-		}
-		return ""
-	default:
-		return ""
+	if len(d.scope.Namespaces) == 0 {
+		delete(d.cfg.Namespaces, d.session.Server)
+	} else {
+		d.cfg.Namespaces[d.session.Server] = d.scope.Namespaces
+	}
+	if err := d.cfg.Save(); err != nil {
+		go d.showInfo(fmt.Sprintf("Erro ao salvar preferências: %v", err))
 	}
 }
 
-func (d *Dashboard) resourceNSFor(box *tview.TextView, line string, currentNS string) string {
-	fields := strings.Fields(line)
-	if len(fields) == 0 {
-		return ""
-	}
-	nsTrim := strings.TrimSpace(currentNS)
-	isAll := nsTrim == "" || strings.EqualFold(nsTrim, "all")
-	if isAll {
-		return fields[0]
+func (d *Dashboard) openModal(title, body string) {
+	d.restoreFocus = d.app.GetFocus()
+	d.modalOpen = true
+	hint := " (Esc fecha)"
+	if d.modalFile != "" {
+		hint = " (Esc fecha · w salva)"
 	}
-	return nsTrim
+	d.modalLogs.SetTitle(title + hint)
+	d.modalLogs.SetText(body)
+	d.modalLogs.ScrollToBeginning()
+	d.pages.AddPage("modalLogs", tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(d.modalLogs, 0, 1, true), true, true)
 }
 
-func (d *Dashboard) resourceNameFor(box *tview.TextView, line string, currentNS string) string {
-	fields := strings.Fields(line)
-	if len(fields) == 0 {
-		return ""
+func (d *Dashboard) closeModal() {
+	if d.logStream != nil {
+		d.logStream.stop()
+		d.logStream = nil
+		d.logSource = nil
+		d.modalLogs.Highlight().SetRegions(false)
 	}
-	nsTrim := strings.TrimSpace(currentNS)
-	isAll := nsTrim == "" || strings.EqualFold(nsTrim, "all")
-	if box == d.infraView || (box == d.storageView && len(fields) > 0) {
-		return fields[0]
+	if d.manifest != nil {
+		d.manifest = nil
+		d.modalLogs.Highlight().SetRegions(false)
 	}
-	if isAll {
-		if len(fields) >= 2 {
-			return fields[1]
-		}
-		return ""
+	if d.edit != nil {
+		d.edit.cleanup()
+		d.edit = nil
+	}
+	d.pages.RemovePage("modalLogs")
+	d.modalOpen = false
+	d.modalFile = ""
+	if d.restoreFocus != nil {
+		d.app.SetFocus(d.restoreFocus)
 	}
-	return fields[0]
 }
 
-func (d *Dashboard) adjustSelection(delta int) {
-	if d.browseBox == nil {
-		return
-	}
-	raw := d.contentCache[d.browseBox]
-	lines := strings.Split(raw, "\n")
-	next := d.findSelectable(d.browseBox, lines, d.selectedLine+delta, delta, false)
-	if next != -1 {
-		d.selectedLine = next
-	}
-	d.highlightBox(d.browseBox)
+func (d *Dashboard) showInfo(msg string) {
+	_ = d.app.QueueUpdateDraw(func() {
+		d.pages.RemovePage("infoPopup")
+		d.infoPopup.SetText(msg)
+		d.pages.AddPage("infoPopup", tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(d.infoPopup, 5, 0, true), true, true)
+	})
+	go func() {
+		time.Sleep(1500 * time.Millisecond)
+		_ = d.app.QueueUpdateDraw(func() {
+			d.pages.RemovePage("infoPopup")
+		})
+	}()
 }
 
 func (d *Dashboard) update() {
 	d.updateMu.Lock()
 	defer d.updateMu.Unlock()
 
-	currentNS := d.ns
-
-	summary := data.BuildSummary(currentNS, d.clientset)
-	nsView, nsNames := data.BuildNamespaces()
-	alerts := data.BuildAlerts(currentNS)
-	cfg := data.BuildConfigGroup(currentNS)
-	net := data.BuildNetworkGroup(currentNS)
-	storage := data.BuildStorageGroup(currentNS)
-	infra := data.BuildInfraGroup()
-	wl := data.BuildWorkloadsGroup(currentNS)
-	pods := dataClampLines(data.BuildPods(currentNS), 30)
-	metrics := data.BuildMetrics(currentNS)
-	events := data.BuildEvents(currentNS)
+	scope := d.scope
+
+	summary := formatSummary(data.BuildSummary(scope, d.backend))
+	namespaces := data.BuildNamespaces(d.backend)
+	nsTable, nsNames := namespaceTable(namespaces, scope)
+	alertRows := data.BuildAlerts(scope, d.backend)
+	alerts := formatAlerts(alertRows)
+	eventsTable := data.BuildEvents(scope, d.backend)
+	events := renderText(eventsTable)
+	recentEvents := renderText(lastRows(eventsTable, eventsShown))
+	tables := map[*listView][]data.Table{
+		d.configView:    data.BuildConfigGroup(scope, d.backend),
+		d.networkView:   data.BuildNetworkGroup(scope, d.backend),
+		d.storageView:   data.BuildStorageGroup(scope, d.backend),
+		d.infraView:     data.BuildInfraGroup(scope, d.backend),
+		d.workloadsView: data.BuildWorkloadsGroup(scope, d.backend),
+		d.podsView:      {data.BuildPods(scope, d.backend)},
+		d.metricsView:   {data.BuildMetrics(scope, d.backend)},
+	}
+	var twins *data.TwinReport
+	var twinLeft, twinRight data.TwinSide
+	if d.twin != nil {
+		twinLeft, twinRight = d.twinSides(scope)
+		report := data.CompareTwins(twinLeft, twinRight)
+		twins = &report
+		summary += "\n" + formatTwinSummary(report, twinLeft.Label, twinRight.Label)
+	}
 
 	_ = d.app.QueueUpdateDraw(func() {
-		d.contentCache[d.namespacesView] = nsView
 		d.contentCache[d.overview] = summary
 		d.contentCache[d.alertsView] = alerts
-		d.contentCache[d.configView] = cfg
-		d.contentCache[d.networkView] = net
-		d.contentCache[d.storageView] = storage
-		d.contentCache[d.infraView] = infra
-		d.contentCache[d.workloadsView] = wl
-		d.contentCache[d.podsView] = pods
-		d.contentCache[d.metricsView] = metrics
 		d.contentCache[d.eventsView] = events
 		d.nsList = nsNames
+		d.nsTable = namespaces
+		d.titleCounts[d.alertsView] = countLabel(len(alertRows), len(alertRows))
+		d.titleCounts[d.eventsView] = countLabel(min(len(eventsTable.Rows), eventsShown), len(eventsTable.Rows))
 
 		if strings.TrimSpace(alerts) == "" {
 			d.borderDefaults[d.alertsView] = tcell.ColorGreen
@@ -688,56 +721,57 @@ func (d *Dashboard) update() {
 		}
 		d.borderDefaults[d.overview] = tcell.ColorLightSkyBlue
 
-		if d.browseBox != nil {
-			d.highlightBox(d.browseBox)
+		d.namespacesView.SetTables([]data.Table{nsTable})
+		d.overview.SetText(summary)
+		d.alertsView.SetText(alerts)
+		d.eventsView.SetText(recentEvents)
+		for box, t := range tables {
+			box.SetTables(t)
+		}
+		if twins != nil {
+			d.baseTitles[d.twinLeft] = "TWINS " + twinLeft.Label
+			d.baseTitles[d.twinRight] = "TWINS " + twinRight.Label
+			d.twinLeft.SetTables(twins.Left)
+			d.twinRight.SetTables(twins.Right)
+			d.twinDiff.SetText(formatTwinDiffs(*twins, twinLeft.Label, twinRight.Label))
 		} else {
-			d.namespacesView.SetText(nsView)
-			d.overview.SetText(summary)
-			d.alertsView.SetText(alerts)
-			d.configView.SetText(cfg)
-			d.networkView.SetText(net)
-			d.storageView.SetText(storage)
-			d.infraView.SetText(infra)
-			d.workloadsView.SetText(wl)
-			d.podsView.SetText(pods)
-			d.metricsView.SetText(metrics)
-			d.eventsView.SetText(events)
-		}
-
-		adjust := func(f *tview.Flex, item tview.Primitive, hasContent bool, minHeight int, keepBorder bool) {
-			if hasContent {
-				f.ResizeItem(item, 0, 1)
-				if box, ok := item.(*tview.TextView); ok {
-					box.SetBorder(true)
-					if strings.TrimSpace(d.contentCache[box]) == "" {
-						box.SetText("")
-					}
-					box.SetBorderColor(tcell.ColorWhite)
-				}
+			d.baseTitles[d.twinLeft], d.baseTitles[d.twinRight] = "TWINS", "TWINS"
+			d.twinLeft.setText("Compare com outro namespace ou contexto: " + twinsUsage)
+			d.twinRight.reset()
+			d.twinDiff.SetText("")
+		}
+		if d.browseBox != nil && !d.browseBox.browsing {
+			d.exitBrowse()
+		}
+		for _, box := range d.titledPanels() {
+			d.refreshTitle(box)
+		}
+
+		adjust := func(f *tview.Flex, box *listView, minHeight int, keepBorder bool) {
+			if box.hasContent() {
+				f.ResizeItem(box, 0, 1)
+				box.SetBorder(true)
+				box.SetBorderColor(tcell.ColorWhite)
+				return
+			}
+			if minHeight > 0 {
+				f.ResizeItem(box, minHeight, 0)
 			} else {
-				if minHeight > 0 {
-					f.ResizeItem(item, minHeight, 0)
-				} else {
-					f.ResizeItem(item, 1, 0)
-				}
-				if box, ok := item.(*tview.TextView); ok {
-					box.SetText("")
-					box.SetBorder(keepBorder)
-					if keepBorder {
-						box.SetBorderColor(tcell.ColorGray)
-					}
-				}
+				f.ResizeItem(box, 1, 0)
+			}
+			box.SetBorder(keepBorder)
+			if keepBorder {
+				box.SetBorderColor(tcell.ColorGray)
 			}
 		}
 
-		adjust(d.clusterPage, d.infraView, strings.TrimSpace(d.contentCache[d.infraView]) != "", 3, true)
-		adjust(d.clusterPage, d.configView, strings.TrimSpace(d.contentCache[d.configView]) != "", 3, true)
-		adjust(d.clusterPage, d.storageView, strings.TrimSpace(d.contentCache[d.storageView]) != "", 3, true)
-		adjust(d.workloadsPage, d.workloadsView, strings.TrimSpace(d.contentCache[d.workloadsView]) != "", 4, true)
-		adjust(d.workloadsPage, d.podsView, strings.TrimSpace(d.contentCache[d.podsView]) != "", 4, true)
-		adjust(d.workloadsPage, d.metricsView, strings.TrimSpace(d.contentCache[d.metricsView]) != "", 3, true)
-		adjust(d.networkPage, d.networkView, strings.TrimSpace(d.contentCache[d.networkView]) != "", 3, true)
-		adjust(d.metricsPage, d.metricsView, strings.TrimSpace(d.contentCache[d.metricsView]) != "", 3, true)
+		adjust(d.clusterPage, d.infraView, 3, true)
+		adjust(d.clusterPage, d.configView, 3, true)
+		adjust(d.clusterPage, d.storageView, 3, true)
+		adjust(d.workloadsPage, d.workloadsView, 4, true)
+		adjust(d.workloadsPage, d.podsView, 4, true)
+		adjust(d.networkPage, d.networkView, 3, true)
+		adjust(d.metricsPage, d.metricsView, 3, true)
 
 		if d.app.GetFocus() == nil {
 			if items := d.focusOrder(); len(items) > 0 {
@@ -755,14 +789,32 @@ func (d *Dashboard) scheduleUpdate() {
 	}
 }
 
+// overlayOpen diz se há prompt, picker, confirmação ou painel na frente,
+// recebendo as teclas.
+func (d *Dashboard) overlayOpen() bool {
+	return d.promptOpen || d.pickerOpen || d.confirmOpen || d.panelOpen
+}
+
 func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
+	if d.overlayOpen() {
+		return ev
+	}
 	if d.modalOpen {
 		if ev.Key() == tcell.KeyEsc {
-			d.pages.RemovePage("modalLogs")
-			d.modalOpen = false
-			if d.restoreFocus != nil {
-				d.app.SetFocus(d.restoreFocus)
-			}
+			d.closeModal()
+			return nil
+		}
+		if d.logStream != nil {
+			return d.handleLogKeys(ev)
+		}
+		if d.manifest != nil {
+			return d.handleManifestKeys(ev)
+		}
+		if d.edit != nil {
+			return d.handleEditKeys(ev)
+		}
+		if ev.Key() == tcell.KeyRune && ev.Rune() == 'w' {
+			d.saveModal()
 			return nil
 		}
 		return ev
@@ -771,17 +823,33 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 	if d.browseBox != nil {
 		switch ev.Key() {
 		case tcell.KeyUp:
-			d.adjustSelection(-1)
+			d.browseBox.move(-1)
 			return nil
 		case tcell.KeyDown:
-			d.adjustSelection(1)
+			d.browseBox.move(1)
 			return nil
+		case tcell.KeyLeft, tcell.KeyRight, tcell.KeyHome, tcell.KeyEnd, tcell.KeyPgUp, tcell.KeyPgDn:
+			// rolagem horizontal e saltos ficam com a própria Table
+			return ev
 		case tcell.KeyEnter:
+			if d.browseBox == d.namespacesView {
+				if row := d.selectedRow(); row != nil {
+					d.useNamespace(row.Name)
+				}
+				return nil
+			}
 			d.openLogsSelected()
 			return nil
 		case tcell.KeyEsc:
 			d.exitBrowse()
 			return nil
+		case tcell.KeyRune:
+			if ev.Rune() == ' ' && d.browseBox == d.namespacesView {
+				if row := d.selectedRow(); row != nil {
+					d.toggleNamespace(row.Name)
+				}
+				return nil
+			}
 		}
 	}
 
@@ -798,18 +866,45 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 	case ev.Key() == tcell.KeyRune && ev.Rune() == 'm':
 		d.setPage("metrics")
 		return nil
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 't':
+		d.setPage("twins")
+		return nil
 	case ev.Key() == tcell.KeyRune && ev.Rune() == 'l':
 		d.openLogsSelected()
 		return nil
+	case ev.Key() == tcell.KeyRune && ev.Rune() == '/':
+		d.openFilter()
+		return nil
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'S':
+		d.openSelector()
+		return nil
+	case ev.Key() == tcell.KeyRune && ev.Rune() == ':':
+		d.openCommand()
+		return nil
+	case ev.Key() == tcell.KeyCtrlN:
+		d.openNamespacePicker("")
+		return nil
+	case ev.Key() == tcell.KeyCtrlK:
+		d.openContextPicker("")
+		return nil
+	case ev.Key() == tcell.KeyRune && (ev.Rune() == 'o' || ev.Rune() == 'O'):
+		if box := d.listFor(d.app.GetFocus()); box != nil {
+			if box.mirror != nil {
+				go d.showInfo("Twins: as linhas ficam alinhadas por nome, sem ordenação")
+				return nil
+			}
+			if ev.Rune() == 'o' {
+				box.cycleSort()
+			} else {
+				box.reverseSort()
+			}
+			d.refreshTitle(box)
+		}
+		return nil
 	case ev.Key() == tcell.KeyRune && ev.Rune() >= '0' && ev.Rune() <= '9':
 		idx := int(ev.Rune() - '0')
 		if idx >= 0 && idx < len(d.nsList) {
-			oldNS := d.ns
-			d.ns = strings.TrimSpace(d.nsList[idx])
-			statusMsg := fmt.Sprintf("Namespace: %s -> %s", displayNS(oldNS), displayNS(d.ns))
-			go d.showInfo(statusMsg)
-			d.exitBrowse()
-			d.scheduleUpdate()
+			d.useNamespace(d.nsList[idx])
 		}
 		return nil
 	case ev.Key() == tcell.KeyLeft:
@@ -823,6 +918,7 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 		if strings.TrimSpace(content) == "" {
 			content = "Sem alertas."
 		}
+		d.modalFile = fileNS(d.scopeLabel()) + "_alerts"
 		d.openModal("ALERTAS", content)
 		return nil
 	case ev.Key() == tcell.KeyRune && ev.Rune() == 'e':
@@ -830,6 +926,7 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 		if strings.TrimSpace(content) == "" {
 			content = "Sem eventos."
 		}
+		d.modalFile = fileNS(d.scopeLabel()) + "_events"
 		d.openModal("EVENTS", content)
 		return nil
 	case ev.Key() == tcell.KeyUp:
@@ -840,9 +937,7 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 		return nil
 	case ev.Key() == tcell.KeyEnter:
 		if d.browseBox == nil {
-			if tv, ok := d.app.GetFocus().(*tview.TextView); ok {
-				d.enterBrowse(tv)
-			}
+			d.enterBrowse(d.listFor(d.app.GetFocus()))
 			return nil
 		}
 		d.openLogsSelected()
@@ -851,6 +946,11 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 			d.exitBrowse()
 			return nil
 		}
+		if box := d.listFor(d.app.GetFocus()); box != nil && !box.filter.Empty() {
+			box.setFilter(data.Filter{})
+			d.refreshTitle(box)
+			return nil
+		}
 	case ev.Key() == tcell.KeyRune && ev.Rune() == 'q':
 		d.app.Stop()
 		return nil
@@ -859,10 +959,117 @@ func (d *Dashboard) handleInput(ev *tcell.EventKey) *tcell.EventKey {
 			d.openDescribeSelected()
 			return nil
 		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'y':
+		if d.browseBox != nil {
+			d.openManifestSelected()
+			return nil
+		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'E':
+		if d.browseBox != nil {
+			d.openEditSelected()
+			return nil
+		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'x':
+		if d.browseBox != nil {
+			d.openActionsSelected()
+			return nil
+		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 's':
+		if d.browseBox != nil {
+			d.openExecSelected()
+			return nil
+		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'f':
+		if d.browseBox != nil {
+			d.openForwardSelected()
+			return nil
+		}
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'F':
+		d.openForwards()
+		return nil
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'D':
+		if d.browseBox != nil {
+			d.deleteSelected()
+			return nil
+		}
 	}
 	return ev
 }
 
+// startBackend liga o watch do backend atual. Com informers o redraw vem dos
+// eventos do watch; o ticker só atualiza idades e métricas.
+func (d *Dashboard) startBackend() {
+	interval := pollInterval
+	if w, ok := d.backend.(data.Watcher); ok {
+		w.Start(func(string) { d.scheduleUpdate() })
+		interval = watchRefresh
+	}
+	d.watchShown()
+	d.ticker.Reset(interval)
+}
+
+// watchShown pede ao backend o watch dos kinds dos boxes na tela: o
+// cabeçalho e a página atual. Secrets e events só são observados depois de
+// aparecerem.
+func (d *Dashboard) watchShown() {
+	w, ok := d.backend.(data.Watcher)
+	if !ok {
+		return
+	}
+	shown := []panel{d.namespacesView, d.eventsView}
+	switch d.currentPage {
+	case "workloads":
+		shown = append(shown, d.workloadsView, d.podsView)
+	case "network":
+		shown = append(shown, d.networkView)
+	case "cluster":
+		shown = append(shown, d.infraView, d.configView, d.storageView)
+	}
+	var kinds []string
+	for _, box := range shown {
+		kinds = append(kinds, d.boxKinds[box]...)
+	}
+	w.Watch(kinds...)
+}
+
+// switchContext troca o contexto do kubeconfig: novo clientset e backend,
+// kubectl com --context e o conjunto de namespaces salvo para o novo cluster.
+func (d *Dashboard) switchContext(name string) {
+	session, err := d.kubeconfig.Connect(name)
+	if err != nil {
+		go d.showInfo(fmt.Sprintf("Erro ao trocar de contexto: %v", err))
+		return
+	}
+	// update() lê backend e scope fora da goroutine da UI
+	d.updateMu.Lock()
+	old := d.backend
+	d.session = session
+	d.backend = d.newBackend(session)
+	d.scope.Namespace = ""
+	d.scope.Namespaces = nil
+	if d.cfg != nil {
+		d.scope.Namespaces = d.cfg.Namespaces[session.Server]
+	}
+	data.SetKubectlContext(session.Name)
+	d.updateMu.Unlock()
+
+	if w, ok := old.(data.Watcher); ok {
+		go w.Stop()
+	}
+	d.startBackend()
+	d.exitBrowse()
+	d.setPlaceholders()
+	d.refreshContextTitle()
+	go d.showInfo("Contexto: " + session.Name)
+	d.scheduleUpdate()
+}
+
+// refreshContextTitle mostra contexto, cluster e usuário ativos no OVERVIEW.
+func (d *Dashboard) refreshContextTitle() {
+	s := d.session
+	d.overview.SetTitle(tview.Escape(fmt.Sprintf("OVERVIEW [ctx %s · cluster %s · user %s]", s.Name, s.Cluster, s.User)))
+}
+
 func (d *Dashboard) captureInterrupt() {
 	go func() {
 		c := make(chan os.Signal, 1)
@@ -879,11 +1086,24 @@ func (d *Dashboard) Run() error {
 	go func() {
 		for range d.updateCh {
 			d.update()
+			time.Sleep(minRedrawInterval)
 		}
 	}()
 
-	d.ticker = time.NewTicker(2 * time.Second)
+	d.ticker = time.NewTicker(pollInterval)
 	defer d.ticker.Stop()
+	d.startBackend()
+	defer d.stopForwards()
+	defer func() {
+		if w, ok := d.backend.(data.Watcher); ok {
+			w.Stop()
+		}
+		if d.twin != nil {
+			if w, ok := d.twin.backend.(data.Watcher); ok {
+				w.Stop()
+			}
+		}
+	}()
 	go func() {
 		for range d.ticker.C {
 			d.scheduleUpdate()
@@ -915,16 +1135,38 @@ func newBox(title string) *tview.TextView {
 	return tv
 }
 
-func dataClampLines(s string, maxLines int) string {
-	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
-	if len(lines) <= maxLines {
-		return strings.Join(lines, "\n")
+// lastRows mantém as n linhas mais recentes (a tabela vem em ordem cronológica).
+func lastRows(t data.Table, n int) data.Table {
+	if len(t.Rows) > n {
+		t.Rows = t.Rows[len(t.Rows)-n:]
+	}
+	return t
+}
+
+// countLabel é o "N of M" dos títulos quando o box mostra só parte das linhas.
+func countLabel(shown, total int) string {
+	switch {
+	case total == 0:
+		return ""
+	case shown < total:
+		return fmt.Sprintf("showing %d of %d", shown, total)
+	}
+	return fmt.Sprintf("%d", total)
+}
+
+func shortDuration(dur time.Duration) string {
+	switch {
+	case dur < time.Minute:
+		return fmt.Sprintf("%ds", int(dur.Seconds()))
+	case dur < time.Hour:
+		return fmt.Sprintf("%dm", int(dur.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(dur.Hours()))
 	}
-	return strings.Join(lines[:maxLines], "\n")
 }
 
 func displayNS(ns string) string {
-	if strings.TrimSpace(ns) == "" {
+	if trimmed := strings.TrimSpace(ns); trimmed == "" || strings.EqualFold(trimmed, "all") {
 		return "ALL"
 	}
 	return ns